@@ -11,6 +11,7 @@ import (
 )
 
 // Client represents the CDN Configuration API client
+// Errors reported by the API are returned as *response.APIError
 type Client struct {
 	*httpclient.Client // HTTP client for making requests
 }
@@ -71,10 +72,11 @@ func (c *Client) GetCdnConfig(ctx context.Context, resourceID int64) (*CdnConfig
 	return &config, nil
 }
 
-// UpdateCdnConfig updates an existing CDN configuration by resourceId
-func (c *Client) UpdateCdnConfig(ctx context.Context, resourceID int64, config *CdnConfiguration) (*CdnConfigurationResponse, error) {
+// UpdateCdnConfig updates an existing CDN configuration by resourceId.
+// Pass httpclient.WithIfMatch to only update the configuration if it still has the expected version.
+func (c *Client) UpdateCdnConfig(ctx context.Context, resourceID int64, config *CdnConfiguration, options ...httpclient.RequestOption) (*CdnConfigurationResponse, error) {
 	path := fmt.Sprintf("/cdn-configs/%d", resourceID)
-	resp, err := c.MakeRequest(ctx, http.MethodPut, path, config)
+	resp, err := c.MakeRequest(ctx, http.MethodPut, path, config, options...)
 	if err != nil {
 		return nil, err
 	}
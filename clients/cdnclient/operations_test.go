@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
 )
 
 // setupMockServer creates a test server that simulates the CDN Configuration API
@@ -482,6 +483,9 @@ func TestCRUDOperations(t *testing.T) {
 	if err == nil {
 		t.Error("GetCdnConfig() expected error for non-existent resource")
 	}
+	if !response.IsNotFound(err) {
+		t.Errorf("GetCdnConfig() expected a not found API error, got %v", err)
+	}
 }
 
 func TestGetCdnComponents(t *testing.T) {
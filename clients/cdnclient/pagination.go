@@ -0,0 +1,53 @@
+package cdnclient
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the page size used when walking all CDN configurations
+const DefaultPageSize = 50
+
+// CdnConfigPages returns an iterator over all pages of CDN configurations, starting at the first page.
+// Iteration stops after the page marked as last or the last of the total number of pages, after an empty page,
+// or after the first error, which is yielded together with a nil page.
+func (c *Client) CdnConfigPages(ctx context.Context, pageSize int) iter.Seq2[*CdnConfigurationPage, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(*CdnConfigurationPage, error) bool) {
+		for pageNumber := 0; ; pageNumber++ {
+			page, err := c.GetCdnConfigsPage(ctx, pageNumber, pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(page, nil) {
+				return
+			}
+
+			// Guard against servers that never mark a page as last
+			if page.Last || page.Empty || len(page.Configs) == 0 {
+				return
+			}
+			if page.TotalPages > 0 && pageNumber+1 >= page.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// GetAllCdnConfigs retrieves every CDN configuration for the authenticated account by walking all pages
+func (c *Client) GetAllCdnConfigs(ctx context.Context, pageSize int) ([]CdnConfigurationResponse, error) {
+	var configs []CdnConfigurationResponse
+	for page, err := range c.CdnConfigPages(ctx, pageSize) {
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, page.Configs...)
+	}
+
+	return configs, nil
+}
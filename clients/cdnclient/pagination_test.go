@@ -0,0 +1,202 @@
+package cdnclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// setupPagedMockServer creates a test server serving totalConfigs CDN configurations in pages
+func setupPagedMockServer(t *testing.T, totalConfigs int, requestedPages *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cdn-configs" || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		*requestedPages = append(*requestedPages, page)
+
+		if page == 99 {
+			w.WriteHeader(http.StatusInternalServerError)
+			writeResponse(t, w, []byte(`{"error":"Internal server error"}`))
+			return
+		}
+
+		totalPages := (totalConfigs + size - 1) / size
+		start := min(page*size, totalConfigs)
+		end := min(start+size, totalConfigs)
+
+		configs := make([]CdnConfigurationResponse, 0, end-start)
+		for i := start; i < end; i++ {
+			configs = append(configs, CdnConfigurationResponse{ResourceID: int64(i + 1)})
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(CdnConfigurationPage{
+			Configs:          configs,
+			TotalElements:    totalConfigs,
+			TotalPages:       totalPages,
+			PageNumber:       page,
+			PageSize:         size,
+			NumberOfElements: len(configs),
+			First:            page == 0,
+			Last:             page >= totalPages-1,
+			Empty:            len(configs) == 0,
+		}); err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+}
+
+func TestGetAllCdnConfigs(t *testing.T) {
+	tests := []struct {
+		name          string
+		totalConfigs  int
+		pageSize      int
+		expectedPages []int
+	}{
+		{
+			name:          "multiple_pages",
+			totalConfigs:  7,
+			pageSize:      3,
+			expectedPages: []int{0, 1, 2},
+		},
+		{
+			name:          "exact_page_boundary",
+			totalConfigs:  6,
+			pageSize:      3,
+			expectedPages: []int{0, 1},
+		},
+		{
+			name:          "single_page",
+			totalConfigs:  2,
+			pageSize:      10,
+			expectedPages: []int{0},
+		},
+		{
+			name:          "no_configs",
+			totalConfigs:  0,
+			pageSize:      10,
+			expectedPages: []int{0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestedPages []int
+			server := setupPagedMockServer(t, tc.totalConfigs, &requestedPages)
+			defer server.Close()
+
+			client := givenCdnClient(server.URL)
+
+			configs, err := client.GetAllCdnConfigs(context.Background(), tc.pageSize)
+			if err != nil {
+				t.Fatalf("GetAllCdnConfigs() error = %v", err)
+			}
+
+			if len(configs) != tc.totalConfigs {
+				t.Errorf("GetAllCdnConfigs() expected %d configs, got %d", tc.totalConfigs, len(configs))
+			}
+			for i, config := range configs {
+				if config.ResourceID != int64(i+1) {
+					t.Errorf("GetAllCdnConfigs() expected resource ID %d at index %d, got %d", i+1, i, config.ResourceID)
+				}
+			}
+
+			if len(requestedPages) != len(tc.expectedPages) {
+				t.Fatalf("Expected pages %v to be requested, got %v", tc.expectedPages, requestedPages)
+			}
+			for i, page := range tc.expectedPages {
+				if requestedPages[i] != page {
+					t.Errorf("Expected pages %v to be requested, got %v", tc.expectedPages, requestedPages)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCdnConfigPagesStopsOnError(t *testing.T) {
+	var requestedPages []int
+	server := setupPagedMockServer(t, 1000, &requestedPages)
+	defer server.Close()
+
+	client := givenCdnClient(server.URL)
+
+	var pages int
+	var lastErr error
+	// The mock server fails on page 99
+	for _, err := range client.CdnConfigPages(context.Background(), 10) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		pages++
+	}
+
+	if lastErr == nil {
+		t.Error("CdnConfigPages() expected an error, got none")
+	}
+	if pages != 99 {
+		t.Errorf("CdnConfigPages() expected 99 pages before the error, got %d", pages)
+	}
+}
+
+func TestCdnConfigPagesStopsEarly(t *testing.T) {
+	var requestedPages []int
+	server := setupPagedMockServer(t, 100, &requestedPages)
+	defer server.Close()
+
+	client := givenCdnClient(server.URL)
+
+	for page, err := range client.CdnConfigPages(context.Background(), 10) {
+		if err != nil {
+			t.Fatalf("CdnConfigPages() error = %v", err)
+		}
+		if page.PageNumber == 1 {
+			break
+		}
+	}
+
+	if len(requestedPages) != 2 {
+		t.Errorf("Expected 2 pages to be requested after breaking early, got %d", len(requestedPages))
+	}
+}
+
+func TestCdnConfigPagesStopsAtTotalPages(t *testing.T) {
+	var requests int
+	// The server returns full pages without ever marking one as last
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(CdnConfigurationPage{
+			Configs:    []CdnConfigurationResponse{{ResourceID: int64(page + 1)}},
+			TotalPages: 3,
+			PageNumber: page,
+		}); err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var pages int
+	for _, err := range givenCdnClient(server.URL).CdnConfigPages(context.Background(), 1) {
+		if err != nil {
+			t.Fatalf("CdnConfigPages() error = %v", err)
+		}
+		pages++
+		if pages > 3 {
+			break
+		}
+	}
+
+	if pages != 3 || requests != 3 {
+		t.Errorf("CdnConfigPages() expected to stop after 3 pages, got %d pages and %d requests", pages, requests)
+	}
+}
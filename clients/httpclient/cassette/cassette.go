@@ -0,0 +1,349 @@
+// Package cassette records API requests and responses to files and replays them, so tests that need the
+// live API can run offline.
+//
+// A Recorder wraps the transport of an httpclient.Client through ClientOption. In record mode, requests
+// are sent to the API and the sanitized interactions are saved to a cassette file when the recorder is
+// stopped. In replay mode, every request is answered from the cassette, and requests without a matching
+// recorded interaction fail. Auth tokens, credentials and other secrets are scrubbed from cassettes, and
+// timestamps are normalized so re-recording does not produce spurious diffs.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+)
+
+// Mode controls whether a Recorder sends requests to the API, records them or replays them
+type Mode string
+
+const (
+	// ModeRecord sends requests to the API and saves the interactions to the cassette
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests from the cassette without contacting the API
+	ModeReplay Mode = "replay"
+	// ModePassthrough sends requests to the API without recording them
+	ModePassthrough Mode = "passthrough"
+)
+
+// ModeEnvVar is the environment variable selecting the mode of recorders created with ModeFromEnv
+const ModeEnvVar = "MULTICDN_CASSETTE_MODE"
+
+const (
+	// Redacted replaces secrets in cassettes
+	Redacted = "REDACTED"
+	// NormalizedTimestamp replaces timestamps in cassettes
+	NormalizedTimestamp = "2000-01-01T00:00:00Z"
+)
+
+// redactedHeaders are headers whose values are never written to a cassette
+var redactedHeaders = []string{
+	http.CanonicalHeaderKey("x-cns-security-token"),
+	http.CanonicalHeaderKey("Authorization"),
+	http.CanonicalHeaderKey("Proxy-Authorization"),
+	http.CanonicalHeaderKey("Cookie"),
+	http.CanonicalHeaderKey("Set-Cookie"),
+}
+
+// timestampPattern matches RFC 3339 timestamps, which are normalized in cassettes
+var timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+
+// ModeFromEnv returns the mode set in the MULTICDN_CASSETTE_MODE environment variable,
+// or the given default mode if the variable is not set
+func ModeFromEnv(defaultMode Mode) (Mode, error) {
+	value := os.Getenv(ModeEnvVar)
+	if value == "" {
+		return defaultMode, nil
+	}
+
+	switch mode := Mode(value); mode {
+	case ModeRecord, ModeReplay, ModePassthrough:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q, expected %q, %q or %q", ModeEnvVar, value, ModeRecord, ModeReplay, ModePassthrough)
+	}
+}
+
+// Cassette is the file format of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"` // Path including the query string, without the scheme and host
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder records or replays the requests of HTTP clients it is installed in
+type Recorder struct {
+	path    string
+	mode    Mode
+	secrets []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// Option allows for customization of the recorder
+type Option func(*Recorder)
+
+// WithRedactedValues adds secrets, such as the API key and secret, that are replaced in recorded
+// paths, headers and bodies
+func WithRedactedValues(values ...string) Option {
+	return func(r *Recorder) {
+		for _, value := range values {
+			if value != "" {
+				r.secrets = append(r.secrets, value)
+			}
+		}
+	}
+}
+
+// New creates a recorder for the cassette file at the given path. In replay mode the cassette must exist.
+func New(path string, mode Mode, options ...Option) (*Recorder, error) {
+	recorder := &Recorder{
+		path: path,
+		mode: mode,
+	}
+
+	for _, option := range options {
+		option(recorder)
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("error parsing cassette %s: %w", path, err)
+		}
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	}
+
+	return recorder, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// ClientOption installs the recorder in an HTTP client
+func (r *Recorder) ClientOption() httpclient.ClientOption {
+	return httpclient.WithTransportWrapper(r.Wrap)
+}
+
+// Wrap returns a transport recording or replaying the requests sent through the given transport
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		switch r.mode {
+		case ModeRecord:
+			return r.record(next, req)
+		case ModeReplay:
+			return r.replay(req)
+		default:
+			return next.RoundTrip(req)
+		}
+	})
+}
+
+// Stop saves the recorded interactions to the cassette in record mode
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("error creating cassette directory: %w", err)
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// record sends a request to the API and adds the sanitized interaction to the cassette
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		// Failed requests have no response to replay, so they are not recorded
+		return nil, err
+	}
+
+	responseBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   r.scrub(req.URL.RequestURI()),
+			Header: r.sanitizeHeader(req.Header),
+			Body:   r.sanitizeBody(requestBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.sanitizeHeader(resp.Header),
+			Body:       r.sanitizeBody(responseBody),
+		},
+	})
+
+	return resp, nil
+}
+
+// replay answers a request with the first recorded interaction matching it that was not replayed yet
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	path := r.scrub(req.URL.RequestURI())
+	body := canonicalBody(r.sanitizeBody(requestBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.Path != path ||
+			canonicalBody(interaction.Request.Body) != body {
+			continue
+		}
+
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no unused interaction in cassette %s matches %s %s", r.path, req.Method, path)
+}
+
+// scrub replaces the secrets of the recorder in a value
+func (r *Recorder) scrub(value string) string {
+	for _, secret := range r.secrets {
+		value = strings.ReplaceAll(value, secret, Redacted)
+	}
+
+	return value
+}
+
+// sanitizeHeader returns a copy of a header with sensitive values redacted and dates normalized
+func (r *Recorder) sanitizeHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	sanitized := make(http.Header, len(header))
+	for name, values := range header {
+		switch {
+		case slices.Contains(redactedHeaders, http.CanonicalHeaderKey(name)):
+			sanitized[name] = []string{Redacted}
+		case http.CanonicalHeaderKey(name) == "Date":
+			sanitized[name] = []string{"Sat, 01 Jan 2000 00:00:00 GMT"}
+		default:
+			for _, value := range values {
+				sanitized[name] = append(sanitized[name], r.scrub(value))
+			}
+		}
+	}
+
+	return sanitized
+}
+
+// sanitizeBody returns a body with secrets redacted and timestamps normalized
+func (r *Recorder) sanitizeBody(body []byte) string {
+	return timestampPattern.ReplaceAllString(r.scrub(string(body)), NormalizedTimestamp)
+}
+
+// canonicalBody returns a JSON body in compact form with sorted object keys, so equivalent bodies match.
+// Other bodies are returned as is.
+func canonicalBody(body string) string {
+	var value any
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+
+	return string(canonical)
+}
+
+// readBody reads a request or response body and replaces it with an unread copy
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	closeErr := (*body).Close()
+	if err = errors.Join(err, closeErr); err != nil {
+		return nil, fmt.Errorf("error reading body: %w", err)
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls the function
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
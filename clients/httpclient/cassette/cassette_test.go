@@ -0,0 +1,126 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/multicdntest"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	const (
+		apiKey    = "key-4b7e21"
+		apiSecret = "secret-c93a05"
+	)
+
+	path := filepath.Join(t.TempDir(), "cassettes", "lifecycle.json")
+	ctx := context.Background()
+	noRetry := httpclient.WithRetryPolicy(httpclient.RetryPolicy{MaxAttempts: 1})
+
+	// lifecycle creates, updates and reads a preference, returning the description read back
+	lifecycle := func(client *preferenceclient.Client) (string, error) {
+		preference := &preferenceclient.Preference{ResourceID: 123, Description: "Created with " + apiKey}
+		if err := client.CreatePreference(ctx, preference); err != nil {
+			return "", err
+		}
+		preference.Description = "Updated"
+		if err := client.UpdatePreference(ctx, 123, preference); err != nil {
+			return "", err
+		}
+		read, err := client.GetPreference(ctx, 123)
+		if err != nil {
+			return "", err
+		}
+		return read.Description, nil
+	}
+
+	server := multicdntest.NewServer(multicdntest.WithCredentials(apiKey, apiSecret))
+	recorder, err := New(path, ModeRecord, WithRedactedValues(apiKey, apiSecret))
+	if err != nil {
+		t.Fatalf("Error creating recorder: %v", err)
+	}
+
+	if _, err := lifecycle(preferenceclient.New(server.NewClient(noRetry, recorder.ClientOption()))); err != nil {
+		t.Fatalf("Error recording requests: %v", err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("Error saving cassette: %v", err)
+	}
+	serverURL := server.URL
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading cassette: %v", err)
+	}
+	cassette := string(data)
+	for _, secret := range []string{apiKey, apiSecret} {
+		if strings.Contains(cassette, secret) {
+			t.Errorf("Expected %q to be scrubbed from the cassette:\n%s", secret, cassette)
+		}
+	}
+	var recorded Cassette
+	if err := json.Unmarshal(data, &recorded); err != nil || len(recorded.Interactions) != 3 {
+		t.Fatalf("Expected 3 recorded interactions, got %+v (error: %v)", recorded, err)
+	}
+	if token := recorded.Interactions[0].Request.Header.Get("x-cns-security-token"); token != Redacted {
+		t.Errorf("Expected the auth token to be redacted, got %q", token)
+	}
+	if !strings.Contains(cassette, NormalizedTimestamp) {
+		t.Errorf("Expected the last updated timestamp to be normalized:\n%s", cassette)
+	}
+
+	// The server is gone, so requests can only be answered from the cassette
+	recorder, err = New(path, ModeReplay, WithRedactedValues(apiKey, apiSecret))
+	if err != nil {
+		t.Fatalf("Error loading cassette: %v", err)
+	}
+	client := preferenceclient.New(httpclient.New(serverURL, "other-key", "other-secret", noRetry, recorder.ClientOption()))
+
+	description, err := lifecycle(client)
+	if err != nil {
+		t.Fatalf("Error replaying requests: %v", err)
+	}
+	if description != "Updated" {
+		t.Errorf("Expected the recorded description, got %q", description)
+	}
+
+	// Every interaction is replayed once, so repeating a request fails
+	if _, err := client.GetPreference(ctx, 123); err == nil || !strings.Contains(err.Error(), "no unused interaction in cassette") {
+		t.Errorf("Expected an unmatched request to fail, got %v", err)
+	}
+}
+
+func TestNewReplayWithoutCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("Expected an error for a missing cassette")
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  Mode
+		expectErr bool
+	}{
+		{value: "", expected: ModeReplay},
+		{value: "record", expected: ModeRecord},
+		{value: "passthrough", expected: ModePassthrough},
+		{value: "rewind", expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Setenv(ModeEnvVar, tc.value)
+
+		mode, err := ModeFromEnv(ModeReplay)
+		if (err != nil) != tc.expectErr || mode != tc.expected {
+			t.Errorf("ModeFromEnv with %q = %q, %v; expected %q", tc.value, mode, err, tc.expected)
+		}
+	}
+}
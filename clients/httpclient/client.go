@@ -7,35 +7,72 @@ import (
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+// DefaultRequestTimeout bounds a single request attempt when no WithRequestTimeout option is given
+const DefaultRequestTimeout = 30 * time.Second
+
 // Client represents the agnostic http client
 type Client struct {
-	baseURL    string
-	apiKey     string
-	apiSecret  string
-	httpClient *http.Client
+	baseURL           string
+	apiKey            string
+	apiSecret         string
+	httpClient        *http.Client
+	transportConfig   TransportConfig
+	transportWrappers []func(http.RoundTripper) http.RoundTripper
+	retryPolicy       RetryPolicy
+	requestTimeout    time.Duration
+	requestLogging    bool
+	redactedValues    []string
+	rateLimiter       *rateLimiter
+	inFlight          chan struct{} // Semaphore of attempts in flight, nil if unlimited
 }
 
 // ClientOption allows for customization of the client
 type ClientOption func(*Client)
 
+// WithRequestTimeout bounds every request attempt, including reading the response body.
+// A failed attempt is retried according to the RetryPolicy, so the context of the request
+// bounds the total duration. A timeout of zero disables the per-attempt limit.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// RequestOption allows for customization of a single request, applied to every attempt
+type RequestOption func(*http.Request)
+
+// WithIfMatch makes a request conditional on the resource still having the given version.
+// The version is sent as a strong entity tag in the If-Match header, so the API rejects the request
+// with 412 Precondition Failed if the resource was modified since that version was read.
+func WithIfMatch(version string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set("If-Match", strconv.Quote(version))
+	}
+}
+
 // New creates a new agnostic HTTP client with the provided base URL, API key, and API secret.
 // It accepts optional ClientOption functions to customize the client further.
 // The base URL should be the root endpoint of the API, e.g., "https://api.example.com/v1".
 // The API key and secret are used for authentication in requests.
-// The client uses the default HTTP client from the net/http package, which can be customized with options.
+// Connections are made by a transport built from the TransportConfig, which can be customized with options.
 // The client is designed to be used for making authenticated requests to an API that requires HMAC authentication.
 func New(baseURL, apiKey, apiSecret string, options ...ClientOption) *Client {
 	client := &Client{
-		baseURL:    baseURL,
-		apiKey:     apiKey,
-		apiSecret:  apiSecret,
-		httpClient: http.DefaultClient,
+		baseURL:         baseURL,
+		apiKey:          apiKey,
+		apiSecret:       apiSecret,
+		transportConfig: DefaultTransportConfig(),
+		retryPolicy:     DefaultRetryPolicy(),
+		requestTimeout:  DefaultRequestTimeout,
+		requestLogging:  true,
 	}
 
 	// Apply options
@@ -43,33 +80,127 @@ func New(baseURL, apiKey, apiSecret string, options ...ClientOption) *Client {
 		option(client)
 	}
 
+	// Build the transport once all transport options are known
+	var transport http.RoundTripper = NewTransport(client.transportConfig)
+	for _, wrap := range client.transportWrappers {
+		transport = wrap(transport)
+	}
+	client.httpClient = &http.Client{
+		Transport: transport,
+	}
+
 	return client
 }
 
 // generateAuthToken generates an HMAC token using the API key and secret
 func (c *Client) generateAuthToken() (string, error) {
 	timestamp := fmt.Sprintf("%d", time.Now().UTC().UnixMilli())
-	hmacHash := computeHMAC(c.apiSecret, timestamp)
+	hmacHash := ComputeHMAC(c.apiSecret, timestamp)
 	return fmt.Sprintf("%s:%s:%s", c.apiKey, hmacHash, timestamp), nil
 }
 
-// computeHMAC generates base64-encoded HMAC-SHA1 digest
-func computeHMAC(secretKey, timestamp string) string {
+// ComputeHMAC generates the base64-encoded HMAC-SHA1 digest of a token timestamp.
+// It is exported so that fake API servers can verify auth tokens.
+func ComputeHMAC(secretKey, timestamp string) string {
 	h := hmac.New(sha1.New, []byte(secretKey))
 	h.Write([]byte(timestamp))
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
 
 // MakeRequest is the core function to make HTTP requests.
-func (c *Client) MakeRequest(ctx context.Context, method, path string, body any) (*http.Response, error) {
+// Requests failing with a retryable status or transport error are retried according to the
+// client's RetryPolicy. The body is replayed and a fresh auth token is generated on every attempt.
+// The request options are applied to every attempt, each of which is bounded by the request timeout.
+// Every attempt first waits for the client's rate limit and concurrency limit, if set.
+func (c *Client) MakeRequest(ctx context.Context, method, path string, body any, options ...RequestOption) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
 
-	var bodyReader io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("error marshaling request body: %w", err)
 		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error executing request: %w", err)
+		}
+
+		attemptCtx, cancelAttempt := c.attemptContext(ctx)
+		cancel := func() {
+			cancelAttempt()
+			release()
+		}
+
+		// Build a new request for each attempt so the body and auth token are fresh
+		req, err := c.newRequest(attemptCtx, method, url, jsonData)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		for _, option := range options {
+			option(req)
+		}
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			cancel()
+			if ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("no response within the request timeout of %s: %w", c.requestTimeout, err)
+			}
+			resp, err = nil, fmt.Errorf("error executing request: %w", err)
+		} else {
+			// Keep the deadline and concurrency slot until the caller is done reading the body
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		}
+		c.logAttempt(ctx, req, jsonData, resp, err, attempt, time.Since(start))
+
+		if ctx.Err() != nil || attempt >= c.retryPolicy.MaxAttempts || !shouldRetry(method, resp, err) {
+			return resp, err
+		}
+
+		delay := c.retryPolicy.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("error executing request: %w", err)
+		}
+	}
+}
+
+// attemptContext derives the context of a single attempt, bounded by the request timeout if set
+func (c *Client) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.requestTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.requestTimeout)
+}
+
+// cancelOnClose releases the context and concurrency slot of an attempt once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel func()
+}
+
+// Close closes the body and releases the attempt
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// newRequest builds an authenticated request carrying the given JSON body
+func (c *Client) newRequest(ctx context.Context, method, url string, jsonData []byte) (*http.Request, error) {
+	var bodyReader io.Reader
+	if jsonData != nil {
 		bodyReader = bytes.NewReader(jsonData)
 	}
 
@@ -87,9 +218,5 @@ func (c *Client) MakeRequest(ctx context.Context, method, path string, body any)
 	}
 	req.Header.Set("x-cns-security-token", token)
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error executing request: %w", err)
-	}
-	return resp, nil
+	return req, nil
 }
@@ -212,6 +212,46 @@ func TestMakeRequest(t *testing.T) {
 	}
 }
 
+func TestMakeRequestWithIfMatch(t *testing.T) {
+	var ifMatch []string
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+
+		// Fail the first attempt so the header is checked on the retry as well
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-key", "test-secret", WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+
+	resp, err := client.MakeRequest(context.Background(), http.MethodPut, "/cdn-configs/123", map[string]any{"resourceId": 123}, WithIfMatch("1.0"))
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(ifMatch) != 2 || ifMatch[0] != `"1.0"` || ifMatch[1] != `"1.0"` {
+		t.Errorf("Expected If-Match %q on both attempts, got %q", `"1.0"`, ifMatch)
+	}
+
+	// Requests without the option are unconditional
+	resp, err = client.MakeRequest(context.Background(), http.MethodGet, "/cdn-configs/123", nil)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := ifMatch[len(ifMatch)-1]; got != "" {
+		t.Errorf("Expected no If-Match header, got %q", got)
+	}
+}
+
 func TestContextCancellation(t *testing.T) {
 	// Create a test server with a delay
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -244,6 +284,69 @@ func TestContextCancellation(t *testing.T) {
 	}
 }
 
+func TestRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		hungAttempts int
+		maxAttempts  int
+		expectErr    bool
+	}{
+		{
+			name:         "single_attempt_times_out",
+			hungAttempts: 1,
+			maxAttempts:  1,
+			expectErr:    true,
+		},
+		{
+			name:         "hung_attempt_is_retried",
+			hungAttempts: 1,
+			maxAttempts:  2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts <= tc.hungAttempts {
+					// Hang until the client gives up on the attempt
+					select {
+					case <-r.Context().Done():
+					case <-time.After(5 * time.Second):
+					}
+					return
+				}
+				_, _ = w.Write([]byte(`{"resourceId": 123}`))
+			}))
+			defer server.Close()
+
+			client := New(server.URL, "test-key", "test-secret",
+				WithRequestTimeout(50*time.Millisecond),
+				WithRetryPolicy(RetryPolicy{MaxAttempts: tc.maxAttempts}),
+			)
+
+			resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/preference/123", nil)
+			if tc.expectErr {
+				if err == nil || !strings.Contains(err.Error(), "no response within the request timeout of 50ms") {
+					t.Fatalf("Expected a request timeout error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error making request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			// The body stays readable after MakeRequest returns
+			body, err := io.ReadAll(resp.Body)
+			if err != nil || string(body) != `{"resourceId": 123}` {
+				t.Errorf("Expected the response body, got %q (error: %v)", body, err)
+			}
+		})
+	}
+}
+
 // computeHMACTest is copied from client.go for test verification
 func computeHMACTest(secretKey, timestamp string) string {
 	h := hmac.New(sha1.New, []byte(secretKey))
@@ -0,0 +1,162 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces secrets in log output
+const redacted = "***"
+
+// traceLogLevels are the values of the log level environment variables that record TRACE entries
+var traceLogLevels = []string{"TRACE", "JSON"}
+
+// redactedHeaders are request headers whose values are never logged
+var redactedHeaders = []string{
+	http.CanonicalHeaderKey("x-cns-security-token"),
+	http.CanonicalHeaderKey("Authorization"),
+	http.CanonicalHeaderKey("Proxy-Authorization"),
+}
+
+// WithRequestLogging enables or disables logging of requests through tflog, which is enabled by default.
+// Method, URL, status and latency are logged at DEBUG level, headers and bodies at TRACE level.
+// The auth token header and the API key and secret are always redacted.
+//
+// Headers and bodies are only logged, and response bodies only buffered, when TF_LOG_PROVIDER, or TF_LOG
+// if it is unset, is TRACE. Terraform passes its log level to the provider in these variables.
+func WithRequestLogging(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.requestLogging = enabled
+	}
+}
+
+// WithRedactedValues adds secrets that must never appear in logged requests and responses,
+// in addition to the API key and secret
+func WithRedactedValues(values ...string) ClientOption {
+	return func(c *Client) {
+		c.redactedValues = append(c.redactedValues, values...)
+	}
+}
+
+// loggingContext returns a context whose log entries have all secrets of the client masked
+func (c *Client) loggingContext(ctx context.Context) context.Context {
+	secrets := make([]string, 0, len(c.redactedValues)+2)
+	for _, secret := range append([]string{c.apiKey, c.apiSecret}, c.redactedValues...) {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	return tflog.MaskLogStrings(ctx, secrets...)
+}
+
+// logAttempt logs the outcome of a single request attempt. The response body is logged once the
+// caller has read and closed it, so it is wrapped rather than consumed here.
+func (c *Client) logAttempt(ctx context.Context, req *http.Request, requestBody []byte, resp *http.Response, err error, attempt int, latency time.Duration) {
+	if !c.requestLogging {
+		return
+	}
+
+	ctx = c.loggingContext(ctx)
+	fields := map[string]interface{}{
+		"http_method":     req.Method,
+		"http_url":        req.URL.String(),
+		"http_attempt":    attempt,
+		"http_latency_ms": latency.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "API request failed", fields)
+		return
+	}
+
+	fields["http_status"] = resp.StatusCode
+	tflog.Debug(ctx, "API request completed", fields)
+
+	if !traceLoggingEnabled() {
+		return
+	}
+
+	tflog.Trace(ctx, "API request", map[string]interface{}{
+		"http_method":          req.Method,
+		"http_url":             req.URL.String(),
+		"http_request_headers": formatHeaders(req.Header),
+		"http_request_body":    string(requestBody),
+	})
+
+	resp.Body = &loggedBody{
+		ReadCloser: resp.Body,
+		log: func(body []byte) {
+			tflog.Trace(ctx, "API response", map[string]interface{}{
+				"http_method":           req.Method,
+				"http_url":              req.URL.String(),
+				"http_status":           resp.StatusCode,
+				"http_response_headers": formatHeaders(resp.Header),
+				"http_response_body":    string(body),
+			})
+		},
+	}
+}
+
+// traceLoggingEnabled reports whether Terraform records TRACE log entries of the provider. tflog does not
+// expose its level, so it is taken from the environment variables Terraform sets it with.
+func traceLoggingEnabled() bool {
+	if os.Getenv("TF_ACC_LOG_PATH") != "" {
+		// Acceptance tests always log at TRACE to this file
+		return true
+	}
+
+	level := os.Getenv("TF_LOG_PROVIDER")
+	if level == "" {
+		level = os.Getenv("TF_LOG")
+	}
+	return slices.Contains(traceLogLevels, strings.ToUpper(level))
+}
+
+// formatHeaders formats headers as sorted "Name: value" lines, with the values of sensitive headers redacted
+func formatHeaders(header http.Header) string {
+	lines := make([]string, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if slices.Contains(redactedHeaders, http.CanonicalHeaderKey(name)) {
+			value = redacted
+		}
+		lines = append(lines, name+": "+value)
+	}
+	slices.Sort(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+// loggedBody records a response body while it is read and logs it when closed
+type loggedBody struct {
+	io.ReadCloser
+	buf    bytes.Buffer
+	log    func(body []byte)
+	logged bool
+}
+
+// Read reads from the body, recording the data read
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	return n, err
+}
+
+// Close logs the recorded body and closes the underlying body
+func (b *loggedBody) Close() error {
+	if !b.logged {
+		b.logged = true
+		b.log(b.buf.Bytes())
+	}
+	return b.ReadCloser.Close()
+}
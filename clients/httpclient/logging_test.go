@@ -0,0 +1,177 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRequestLoggingRedactsSecrets(t *testing.T) {
+	const (
+		apiKey    = "key-7d1f0c"
+		apiSecret = "secret-9a4e2b"
+		extra     = "extra-5c8d3f"
+	)
+
+	setLogLevel(t, "TRACE")
+
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("x-cns-security-token")
+
+		// Echo the request so secrets sent in the body also appear in the response
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := New(server.URL, apiKey, apiSecret, WithRedactedValues(extra), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	body := map[string]string{"description": "uses " + apiKey + ", " + apiSecret + " and " + extra}
+
+	resp, err := client.MakeRequest(ctx, http.MethodPut, "/cdn-configs/123?key="+apiKey, body)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	logged := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Error decoding log output: %v", err)
+	}
+
+	messages := map[string]map[string]interface{}{}
+	for _, entry := range entries {
+		messages[entry["@message"].(string)] = entry
+	}
+
+	completed, ok := messages["API request completed"]
+	if !ok {
+		t.Fatalf("Expected a DEBUG entry for the request, got %v", entries)
+	}
+	if completed["http_method"] != http.MethodPut || completed["http_status"] != float64(http.StatusBadRequest) {
+		t.Errorf("Expected method and status to be logged, got %v", completed)
+	}
+	if _, ok := completed["http_latency_ms"]; !ok {
+		t.Errorf("Expected the latency to be logged, got %v", completed)
+	}
+
+	request, ok := messages["API request"]
+	if !ok || !strings.Contains(request["http_request_headers"].(string), "X-Cns-Security-Token: ***") {
+		t.Errorf("Expected the request headers with a redacted token at TRACE, got %v", request)
+	}
+	if response, ok := messages["API response"]; !ok || !strings.Contains(response["http_response_body"].(string), "description") {
+		t.Errorf("Expected the response body at TRACE, got %v", response)
+	}
+
+	signature := strings.Split(token, ":")[1]
+	for name, secret := range map[string]string{"API key": apiKey, "API secret": apiSecret, "redacted value": extra, "auth token signature": signature} {
+		if secret == "" || strings.Contains(logged, secret) {
+			t.Errorf("Expected the %s %q to be redacted from the log output:\n%s", name, secret, logged)
+		}
+	}
+}
+
+func TestRequestLoggingDebugLevel(t *testing.T) {
+	setLogLevel(t, "DEBUG")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"description":"response"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := New(server.URL, "test-key", "test-secret")
+	resp, err := client.MakeRequest(ctx, http.MethodGet, "/cdn-configs", nil)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	if _, ok := resp.Body.(*loggedBody); ok {
+		t.Errorf("Expected the response body not to be buffered for logging below TRACE level")
+	}
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Error decoding log output: %v", err)
+	}
+	if len(entries) != 1 || entries[0]["@message"] != "API request completed" {
+		t.Errorf("Expected only the DEBUG entry for the request, got %v", entries)
+	}
+}
+
+func TestTraceLoggingEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		logLevel    string
+		providerLog string
+		accLogPath  string
+		expected    bool
+	}{
+		{name: "unset"},
+		{name: "trace", logLevel: "trace", expected: true},
+		{name: "json", logLevel: "JSON", expected: true},
+		{name: "debug", logLevel: "DEBUG"},
+		{name: "provider level overrides", logLevel: "TRACE", providerLog: "INFO"},
+		{name: "provider level", logLevel: "WARN", providerLog: "TRACE", expected: true},
+		{name: "acceptance test log file", accLogPath: "/tmp/provider.log", expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("TF_LOG", tc.logLevel)
+			t.Setenv("TF_LOG_PROVIDER", tc.providerLog)
+			t.Setenv("TF_ACC_LOG_PATH", tc.accLogPath)
+
+			if got := traceLoggingEnabled(); got != tc.expected {
+				t.Errorf("traceLoggingEnabled() = %t, expected %t", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRequestLoggingDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := New(server.URL, "test-key", "test-secret", WithRequestLogging(false))
+	resp, err := client.MakeRequest(ctx, http.MethodGet, "/cdn-configs", nil)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if output.Len() > 0 {
+		t.Errorf("Expected no log output, got:\n%s", output.String())
+	}
+}
+
+// setLogLevel sets the log level Terraform would pass to the provider for the duration of the test
+func setLogLevel(t *testing.T, level string) {
+	t.Helper()
+	t.Setenv("TF_ACC_LOG_PATH", "")
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", level)
+}
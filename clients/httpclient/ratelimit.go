@@ -0,0 +1,102 @@
+package httpclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the client to the given average number of request attempts per second,
+// allowing bursts of up to the rate rounded down, and at least one. Retries count as attempts.
+// The limit is shared by everything using the client; a rate of zero or less disables it.
+func WithRateLimit(requestsPerSecond float64) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = nil
+		if requestsPerSecond > 0 {
+			c.rateLimiter = newRateLimiter(requestsPerSecond)
+		}
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of request attempts the client has in flight at once.
+// An attempt holds its slot until its response body is closed. Zero or less disables the limit.
+func WithMaxConcurrentRequests(limit int) ClientOption {
+	return func(c *Client) {
+		c.inFlight = nil
+		if limit > 0 {
+			c.inFlight = make(chan struct{}, limit)
+		}
+	}
+}
+
+// rateLimiter is a token bucket refilled continuously at a fixed rate
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second
+	burst  float64 // Capacity of the bucket
+	tokens float64 // Available tokens, negative while waiters have reserved future tokens
+	last   time.Time
+}
+
+// newRateLimiter creates a rate limiter with a full bucket
+func newRateLimiter(rate float64) *rateLimiter {
+	burst := math.Max(1, math.Floor(rate))
+	return &rateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token, blocking until one is available or the context is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// Reserve the token now so concurrent waiters queue up behind each other
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		// Give the reserved token back to the waiters queued behind
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// acquire waits for a free concurrency slot and a rate limit token before an attempt.
+// The returned function releases the slot and may be called more than once.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = sync.OnceFunc(func() { <-c.inFlight })
+	}
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,157 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// A burst of 20 is served at once, and the 5 further requests wait for 50ms each
+	client := New(server.URL, "key", "secret", WithRateLimit(20), WithRequestLogging(false))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 25 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/test", nil)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected 25 requests at 20 per second with a burst of 20 to take at least 200ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimitContextCancellation(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "key", "secret", WithRateLimit(0.1), WithRequestLogging(false))
+
+	resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// The next token is 10s away, so the request gives up when its context expires
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.MakeRequest(ctx, http.MethodGet, "/test", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to expire while waiting for the rate limit, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected only the first request to reach the server, got %d", got)
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "key", "secret", WithMaxConcurrentRequests(2), WithRequestLogging(false))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/test", nil)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("Expected at most 2 requests in flight, got a peak of %d", got)
+	}
+}
+
+func TestMaxConcurrentRequestsHeldUntilBodyClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "key", "secret", WithMaxConcurrentRequests(1), WithRequestLogging(false))
+
+	resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The open body holds the only slot
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.MakeRequest(ctx, http.MethodGet, "/test", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to wait for the open response, got %v", err)
+	}
+
+	_ = resp.Body.Close()
+	_ = resp.Body.Close()
+
+	resp, err = client.MakeRequest(context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("Expected closing the body to free the slot, got %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNewRateLimiterBurst(t *testing.T) {
+	tests := []struct {
+		rate     float64
+		expected float64
+	}{
+		{rate: 0.5, expected: 1},
+		{rate: 1, expected: 1},
+		{rate: 2.7, expected: 2},
+		{rate: 20, expected: 20},
+	}
+
+	for _, tc := range tests {
+		if got := newRateLimiter(tc.rate).burst; got != tc.expected {
+			t.Errorf("newRateLimiter(%g).burst = %g, expected %g", tc.rate, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// FieldError describes a validation failure reported by the API for a single field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// APIError is returned by Parse when the API responds with a status code of 400 or above
+type APIError struct {
+	StatusCode  int          // HTTP status code of the response
+	Method      string       // HTTP method of the request, if known
+	Path        string       // URL path of the request, if known
+	Code        string       // Machine-readable error code from the response body, if any
+	Message     string       // Human-readable error message from the response body, if any
+	FieldErrors []FieldError // Per-field validation errors from the response body, if any
+	Body        []byte       // Raw response body
+}
+
+// apiErrorBody covers the error payload shapes returned by the API
+type apiErrorBody struct {
+	Code        string       `json:"code"`
+	Message     string       `json:"message"`
+	Error       string       `json:"error"`
+	Errors      []FieldError `json:"errors"`
+	FieldErrors []FieldError `json:"fieldErrors"`
+}
+
+// newAPIError builds an APIError from an error response and its already read body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.Path = resp.Request.URL.Path
+		}
+	}
+
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		apiErr.Code = parsed.Code
+		apiErr.Message = parsed.Message
+		if apiErr.Message == "" {
+			apiErr.Message = parsed.Error
+		}
+		apiErr.FieldErrors = append(parsed.Errors, parsed.FieldErrors...)
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "API error: status code %d", e.StatusCode)
+
+	if e.Method != "" && e.Path != "" {
+		fmt.Fprintf(&sb, " (%s %s)", e.Method, e.Path)
+	}
+
+	switch {
+	case e.Message != "" && e.Code != "":
+		fmt.Fprintf(&sb, ": %s: %s", e.Code, e.Message)
+	case e.Message != "":
+		fmt.Fprintf(&sb, ": %s", e.Message)
+	case len(e.Body) > 0:
+		fmt.Fprintf(&sb, ", body: %s", string(e.Body))
+	}
+
+	for _, fieldErr := range e.FieldErrors {
+		fmt.Fprintf(&sb, "; %s: %s", fieldErr.Field, fieldErr.Message)
+	}
+
+	return sb.String()
+}
+
+// AsAPIError returns the APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// hasStatus reports whether err wraps an APIError with the given status code
+func hasStatus(err error, statusCode int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == statusCode
+}
+
+// IsNotFound reports whether err is an API error with status 404 Not Found
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an API error with status 409 Conflict
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsPreconditionFailed reports whether err is an API error with status 412 Precondition Failed
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// IsUnauthorized reports whether err is an API error with status 401 Unauthorized
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an API error with status 403 Forbidden
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsBadRequest reports whether err is an API error with status 400 Bad Request
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
@@ -0,0 +1,121 @@
+package response_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
+)
+
+func TestParseReturnsAPIError(t *testing.T) {
+	tests := []struct {
+		name                string
+		statusCode          int
+		responseBody        string
+		expectedCode        string
+		expectedMessage     string
+		expectedFieldErrors int
+		expectedErrorText   string
+	}{
+		{
+			name:              "simple_error_body",
+			statusCode:        http.StatusNotFound,
+			responseBody:      `{"error": "Resource not found"}`,
+			expectedMessage:   "Resource not found",
+			expectedErrorText: "API error: status code 404 (GET /cdn-configs/123): Resource not found",
+		},
+		{
+			name:                "structured_error_body",
+			statusCode:          http.StatusBadRequest,
+			responseBody:        `{"code": "VALIDATION_FAILED", "message": "Invalid configuration", "errors": [{"field": "cdns[0].fqdn", "message": "must not be blank"}]}`,
+			expectedCode:        "VALIDATION_FAILED",
+			expectedMessage:     "Invalid configuration",
+			expectedFieldErrors: 1,
+			expectedErrorText:   "API error: status code 400 (GET /cdn-configs/123): VALIDATION_FAILED: Invalid configuration; cdns[0].fqdn: must not be blank",
+		},
+		{
+			name:              "plain_text_body",
+			statusCode:        http.StatusBadRequest,
+			responseBody:      `resource_id must be a positive integer`,
+			expectedErrorText: "API error: status code 400 (GET /cdn-configs/123), body: resource_id must be a positive integer",
+		},
+		{
+			name:              "empty_body",
+			statusCode:        http.StatusConflict,
+			responseBody:      ``,
+			expectedErrorText: "API error: status code 409 (GET /cdn-configs/123)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tc.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+				Request: &http.Request{
+					Method: http.MethodGet,
+					URL:    &url.URL{Path: "/cdn-configs/123"},
+				},
+			}
+
+			err := response.Parse(resp, nil)
+			apiErr, ok := response.AsAPIError(err)
+			if !ok {
+				t.Fatalf("Expected *response.APIError, got %T: %v", err, err)
+			}
+
+			if apiErr.StatusCode != tc.statusCode {
+				t.Errorf("Expected status code %d, got %d", tc.statusCode, apiErr.StatusCode)
+			}
+			if apiErr.Method != http.MethodGet || apiErr.Path != "/cdn-configs/123" {
+				t.Errorf("Expected request GET /cdn-configs/123, got %s %s", apiErr.Method, apiErr.Path)
+			}
+			if apiErr.Code != tc.expectedCode {
+				t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, apiErr.Code)
+			}
+			if apiErr.Message != tc.expectedMessage {
+				t.Errorf("Expected message '%s', got '%s'", tc.expectedMessage, apiErr.Message)
+			}
+			if len(apiErr.FieldErrors) != tc.expectedFieldErrors {
+				t.Errorf("Expected %d field errors, got %d", tc.expectedFieldErrors, len(apiErr.FieldErrors))
+			}
+			if string(apiErr.Body) != tc.responseBody {
+				t.Errorf("Expected raw body '%s', got '%s'", tc.responseBody, string(apiErr.Body))
+			}
+			if err.Error() != tc.expectedErrorText {
+				t.Errorf("Expected error text '%s', got '%s'", tc.expectedErrorText, err.Error())
+			}
+		})
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	notFound := &response.APIError{StatusCode: http.StatusNotFound}
+	conflict := &response.APIError{StatusCode: http.StatusConflict}
+	unauthorized := &response.APIError{StatusCode: http.StatusUnauthorized}
+	preconditionFailed := &response.APIError{StatusCode: http.StatusPreconditionFailed}
+	wrapped := fmt.Errorf("reading config: %w", notFound)
+
+	if !response.IsNotFound(notFound) || !response.IsNotFound(wrapped) {
+		t.Error("Expected IsNotFound to match a 404 error, including when wrapped")
+	}
+	if response.IsNotFound(conflict) {
+		t.Error("Expected IsNotFound not to match a 409 error")
+	}
+	if !response.IsConflict(conflict) {
+		t.Error("Expected IsConflict to match a 409 error")
+	}
+	if !response.IsPreconditionFailed(preconditionFailed) || response.IsPreconditionFailed(conflict) {
+		t.Error("Expected IsPreconditionFailed to match only a 412 error")
+	}
+	if !response.IsUnauthorized(unauthorized) {
+		t.Error("Expected IsUnauthorized to match a 401 error")
+	}
+	if response.IsNotFound(fmt.Errorf("some other error")) || response.IsNotFound(nil) {
+		t.Error("Expected IsNotFound not to match non-API errors")
+	}
+}
@@ -7,7 +7,8 @@ import (
 	"net/http"
 )
 
-// Parse handles deserializing the response body
+// Parse handles deserializing the response body.
+// Responses with a status code of 400 or above are returned as an *APIError.
 func Parse(resp *http.Response, v any) error {
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -21,7 +22,7 @@ func Parse(resp *http.Response, v any) error {
 		if err != nil {
 			return fmt.Errorf("error reading error response body: %w", err)
 		}
-		return fmt.Errorf("API error: status code %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes)
 	}
 
 	if v == nil {
@@ -84,11 +84,11 @@ func TestParseResponse(t *testing.T) {
 					}
 				case *preferenceclient.AvailabilityThresholds:
 					if v.World != 95 {
-						t.Errorf("Expected World 95, got %d", v.World)
+						t.Errorf("Expected World 95, got %g", v.World)
 					}
 					if continent, ok := v.Continents["NA"]; ok {
 						if continent.Default != 98 {
-							t.Errorf("Expected NA default 98, got %d", continent.Default)
+							t.Errorf("Expected NA default 98, got %g", continent.Default)
 						}
 					} else {
 						t.Error("Expected continent 'NA' but not found")
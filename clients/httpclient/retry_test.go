@@ -0,0 +1,237 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy returns a retry policy with short delays suitable for tests
+func testRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+		Jitter:      false,
+	}
+}
+
+func TestMakeRequestRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		failures         int
+		failureCode      int
+		maxAttempts      int
+		expectedAttempts int32
+		expectedStatus   int
+	}{
+		{
+			name:             "retries_service_unavailable_until_success",
+			method:           http.MethodPut,
+			failures:         2,
+			failureCode:      http.StatusServiceUnavailable,
+			maxAttempts:      4,
+			expectedAttempts: 3,
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:             "retries_too_many_requests_for_post",
+			method:           http.MethodPost,
+			failures:         1,
+			failureCode:      http.StatusTooManyRequests,
+			maxAttempts:      4,
+			expectedAttempts: 2,
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:             "does_not_retry_bad_gateway_for_post",
+			method:           http.MethodPost,
+			failures:         1,
+			failureCode:      http.StatusBadGateway,
+			maxAttempts:      4,
+			expectedAttempts: 1,
+			expectedStatus:   http.StatusBadGateway,
+		},
+		{
+			name:             "retries_bad_gateway_for_get",
+			method:           http.MethodGet,
+			failures:         1,
+			failureCode:      http.StatusBadGateway,
+			maxAttempts:      4,
+			expectedAttempts: 2,
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:             "gives_up_after_max_attempts",
+			method:           http.MethodGet,
+			failures:         10,
+			failureCode:      http.StatusServiceUnavailable,
+			maxAttempts:      3,
+			expectedAttempts: 3,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:             "does_not_retry_client_errors",
+			method:           http.MethodGet,
+			failures:         1,
+			failureCode:      http.StatusNotFound,
+			maxAttempts:      4,
+			expectedAttempts: 1,
+			expectedStatus:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			tokens := make(map[string]bool)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+
+				// Every attempt must carry a freshly generated token and the full body
+				token := r.Header.Get("x-cns-security-token")
+				if tokens[token] {
+					t.Errorf("Auth token reused on attempt %d: %s", n, token)
+				}
+				tokens[token] = true
+
+				if tc.method != http.MethodGet {
+					body, err := io.ReadAll(r.Body)
+					if err != nil {
+						t.Errorf("Error reading request body: %v", err)
+					}
+					if string(body) != `{"resourceId":123}` {
+						t.Errorf("Unexpected request body on attempt %d: %s", n, string(body))
+					}
+				}
+
+				if int(n) <= tc.failures {
+					w.WriteHeader(tc.failureCode)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := New(server.URL, "test-key", "test-secret", WithRetryPolicy(testRetryPolicy(tc.maxAttempts)))
+
+			var body any
+			if tc.method != http.MethodGet {
+				body = map[string]int{"resourceId": 123}
+			}
+
+			resp, err := client.MakeRequest(context.Background(), tc.method, "/preference/123", body)
+			if err != nil {
+				t.Fatalf("Error making request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+			if attempts.Load() != tc.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tc.expectedAttempts, attempts.Load())
+			}
+		})
+	}
+}
+
+func TestMakeRequestHonorsRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy(2)
+	policy.MaxBackoff = 5 * time.Second
+	client := New(server.URL, "test-key", "test-secret", WithRetryPolicy(policy))
+
+	start := time.Now()
+	resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/preference/123", nil)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected Retry-After delay of at least 1s, got %s", elapsed)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestMakeRequestRetryStopsOnContextCancellation(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxAttempts: 10, BaseBackoff: time.Second, MaxBackoff: time.Second}
+	client := New(server.URL, "test-key", "test-secret", WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp, err := client.MakeRequest(ctx, http.MethodGet, "/preference/123", nil)
+	if err == nil {
+		t.Error("Expected error due to context cancellation but got none")
+	}
+	if resp != nil {
+		t.Error("Expected nil response after context cancellation")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 10,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, want := range expected {
+		if got := policy.backoff(i+1, nil); got != want {
+			t.Errorf("Retry %d: expected backoff %s, got %s", i+1, want, got)
+		}
+	}
+
+	// Jitter keeps the delay between half and the full backoff
+	policy.Jitter = true
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(3, nil)
+		if got < 200*time.Millisecond || got > 400*time.Millisecond {
+			t.Fatalf("Jittered backoff %s out of range", got)
+		}
+	}
+
+	// Retry-After is capped by MaxBackoff
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if got := policy.backoff(1, resp); got != time.Second {
+		t.Errorf("Expected Retry-After to be capped at 1s, got %s", got)
+	}
+}
@@ -0,0 +1,126 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportConfig controls how connections to the API are established
+type TransportConfig struct {
+	// ProxyURL is the proxy all requests are sent through.
+	// If nil, the proxy is taken from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	ProxyURL *url.URL
+	// RootCAs are the certificate authorities trusted to sign the server certificate.
+	// If nil, the system's trusted certificate authorities are used.
+	RootCAs *x509.CertPool
+	// ClientCertificates are presented to servers requesting mutual TLS authentication
+	ClientCertificates []tls.Certificate
+	// MinTLSVersion is the minimum accepted TLS version, e.g. tls.VersionTLS13
+	MinTLSVersion uint16
+	// InsecureSkipVerify disables verification of the server certificate chain and host name.
+	// It should only be used against lab environments.
+	InsecureSkipVerify bool
+}
+
+// DefaultTransportConfig returns the transport configuration used when no transport options are given
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MinTLSVersion: tls.VersionTLS12,
+	}
+}
+
+// WithProxyURL sends all requests through the given proxy instead of the one from the environment
+func WithProxyURL(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		c.transportConfig.ProxyURL = proxyURL
+	}
+}
+
+// WithRootCAs replaces the system's trusted certificate authorities used to verify the server
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		c.transportConfig.RootCAs = pool
+	}
+}
+
+// WithClientCertificate presents the given certificate to servers requesting mutual TLS authentication
+func WithClientCertificate(certificate tls.Certificate) ClientOption {
+	return func(c *Client) {
+		c.transportConfig.ClientCertificates = append(c.transportConfig.ClientCertificates, certificate)
+	}
+}
+
+// WithMinTLSVersion sets the minimum accepted TLS version, e.g. tls.VersionTLS13
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *Client) {
+		c.transportConfig.MinTLSVersion = version
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the server certificate.
+// It should only be used against lab environments.
+func WithInsecureSkipVerify(skip bool) ClientOption {
+	return func(c *Client) {
+		c.transportConfig.InsecureSkipVerify = skip
+	}
+}
+
+// WithTransportWrapper wraps the transport built from the TransportConfig, e.g. to record or replay requests.
+// Wrappers are applied in the order given, so the last one sees each request first.
+func WithTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transportWrappers = append(c.transportWrappers, wrap)
+	}
+}
+
+// CertPoolFromPEM returns the system's trusted certificate authorities extended with the
+// PEM-encoded certificates of a CA bundle, for use with WithRootCAs
+func CertPoolFromPEM(bundle []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("no PEM-encoded certificates found")
+	}
+
+	return pool, nil
+}
+
+// NewTransport builds an HTTP transport from the given configuration.
+// Connection pooling and timeouts follow http.DefaultTransport, but more idle connections are kept
+// per host since all requests go to the same API.
+func NewTransport(config TransportConfig) *http.Transport {
+	proxy := http.ProxyFromEnvironment
+	if config.ProxyURL != nil {
+		proxy = http.ProxyURL(config.ProxyURL)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:       proxy,
+		DialContext: dialer.DialContext,
+		TLSClientConfig: &tls.Config{
+			RootCAs:            config.RootCAs,
+			Certificates:       config.ClientCertificates,
+			MinVersion:         config.MinTLSVersion,
+			InsecureSkipVerify: config.InsecureSkipVerify,
+		},
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
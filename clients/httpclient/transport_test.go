@@ -0,0 +1,191 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewTransportDefaults(t *testing.T) {
+	transport := New("https://api.example.com", "test-key", "test-secret").httpClient.Transport.(*http.Transport)
+
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected minimum TLS version 1.2, got %x", transport.TLSClientConfig.MinVersion)
+	}
+	if transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("Expected certificate verification to be enabled")
+	}
+	if transport.MaxIdleConnsPerHost != 10 {
+		t.Errorf("Expected 10 idle connections per host, got %d", transport.MaxIdleConnsPerHost)
+	}
+	// The proxy is taken from the environment unless configured
+	if transport.Proxy == nil {
+		t.Error("Expected the proxy to be taken from the environment")
+	}
+}
+
+func TestTransportServerVerification(t *testing.T) {
+	// The server only offers TLS 1.2, so a TLS 1.3 minimum fails the handshake
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	trusted := x509.NewCertPool()
+	trusted.AddCert(server.Certificate())
+
+	tests := []struct {
+		name      string
+		options   []ClientOption
+		expectErr bool
+	}{
+		{
+			name:      "untrusted_certificate",
+			expectErr: true,
+		},
+		{
+			name:    "trusted_ca",
+			options: []ClientOption{WithRootCAs(trusted)},
+		},
+		{
+			name:    "insecure_skip_verify",
+			options: []ClientOption{WithInsecureSkipVerify(true)},
+		},
+		{
+			name:      "minimum_tls_version_not_supported",
+			options:   []ClientOption{WithRootCAs(trusted), WithMinTLSVersion(tls.VersionTLS13)},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := append([]ClientOption{WithRetryPolicy(RetryPolicy{MaxAttempts: 1})}, tc.options...)
+			client := New(server.URL, "test-key", "test-secret", options...)
+
+			resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/cdn-configs", nil)
+			if tc.expectErr {
+				if err == nil {
+					_ = resp.Body.Close()
+					t.Fatal("Expected a TLS error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error making request: %v", err)
+			}
+			_ = resp.Body.Close()
+		})
+	}
+}
+
+func TestTransportClientCertificate(t *testing.T) {
+	var peerCertificates int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peerCertificates = len(r.TLS.PeerCertificates)
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certificate, _ := generateTestCertificate(t)
+	noRetry := WithRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	// Without a client certificate the server rejects the handshake
+	client := New(server.URL, "test-key", "test-secret", noRetry, WithInsecureSkipVerify(true))
+	if resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/cdn-configs", nil); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("Expected the server to require a client certificate")
+	}
+
+	client = New(server.URL, "test-key", "test-secret", noRetry, WithInsecureSkipVerify(true), WithClientCertificate(certificate))
+	resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/cdn-configs", nil)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if peerCertificates != 1 {
+		t.Errorf("Expected the client certificate to be presented, got %d certificates", peerCertificates)
+	}
+}
+
+func TestTransportProxyURL(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests to a proxy carry the absolute URL of the target
+		proxiedURL = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("Error parsing proxy URL: %v", err)
+	}
+
+	client := New("http://api.example.invalid", "test-key", "test-secret", WithProxyURL(proxyURL))
+	resp, err := client.MakeRequest(context.Background(), http.MethodGet, "/cdn-configs/123", nil)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if proxiedURL != "http://api.example.invalid/cdn-configs/123" {
+		t.Errorf("Expected the request to go through the proxy, got %q", proxiedURL)
+	}
+}
+
+func TestCertPoolFromPEM(t *testing.T) {
+	_, certificatePEM := generateTestCertificate(t)
+
+	if _, err := CertPoolFromPEM(certificatePEM); err != nil {
+		t.Errorf("Expected a valid CA bundle, got error: %v", err)
+	}
+	if _, err := CertPoolFromPEM([]byte("not a certificate")); err == nil {
+		t.Error("Expected an error for a bundle without certificates")
+	}
+}
+
+// generateTestCertificate returns a self-signed certificate and its PEM encoding
+func generateTestCertificate(t *testing.T) (tls.Certificate, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %v", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,217 @@
+package multicdntest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/cdnclient"
+)
+
+// CdnConfig returns a copy of the stored CDN configuration with the given resource ID
+func (s *Server) CdnConfig(resourceID int64) (*cdnclient.CdnConfigurationResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config, exists := s.cdnConfigs[resourceID]
+	if !exists {
+		return nil, false
+	}
+
+	config = clone(config)
+	return &config, true
+}
+
+// CdnConfigs returns copies of all stored CDN configurations, ordered by resource ID
+func (s *Server) CdnConfigs() []cdnclient.CdnConfigurationResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedCdnConfigs()
+}
+
+// SetCdnConfig stores a CDN configuration as is, replacing any configuration with the same resource ID.
+// Unlike requests to the API, it does not change the version or modification timestamp.
+func (s *Server) SetCdnConfig(config cdnclient.CdnConfigurationResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cdnConfigs[config.ResourceID] = clone(config)
+}
+
+// DeleteCdnConfig removes a stored CDN configuration, reporting whether it existed
+func (s *Server) DeleteCdnConfig(resourceID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.cdnConfigs[resourceID]
+	delete(s.cdnConfigs, resourceID)
+	return exists
+}
+
+// sortedCdnConfigs returns copies of all stored CDN configurations ordered by resource ID, so pages are stable
+func (s *Server) sortedCdnConfigs() []cdnclient.CdnConfigurationResponse {
+	configs := make([]cdnclient.CdnConfigurationResponse, 0, len(s.cdnConfigs))
+	for _, config := range s.cdnConfigs {
+		configs = append(configs, clone(config))
+	}
+	slices.SortFunc(configs, func(a, b cdnclient.CdnConfigurationResponse) int {
+		return cmp.Compare(a.ResourceID, b.ResourceID)
+	})
+
+	return configs
+}
+
+// listCdnConfigs handles GET /cdn-configs
+func (s *Server) listCdnConfigs(w http.ResponseWriter, r *http.Request) {
+	page, size, err := pageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	configs, totalPages := pageOf(s.sortedCdnConfigs(), page, size)
+	writeJSON(w, http.StatusOK, cdnclient.CdnConfigurationPage{
+		Configs:          configs,
+		TotalElements:    len(s.cdnConfigs),
+		TotalPages:       totalPages,
+		PageNumber:       page,
+		PageSize:         size,
+		NumberOfElements: len(configs),
+		First:            page == 0,
+		Last:             page >= totalPages-1,
+		Empty:            len(configs) == 0,
+	})
+}
+
+// createCdnConfig handles POST /cdn-configs
+func (s *Server) createCdnConfig(w http.ResponseWriter, r *http.Request) {
+	var config cdnclient.CdnConfiguration
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if config.ResourceID <= 0 {
+		writeError(w, http.StatusBadRequest, "resource_id must be a positive integer")
+		return
+	}
+	if _, exists := s.cdnConfigs[config.ResourceID]; exists {
+		writeError(w, http.StatusConflict, fmt.Sprintf("CDN configuration with ID %d already exists", config.ResourceID))
+		return
+	}
+
+	id := fmt.Sprintf("00000000-0000-4000-8000-%012d", config.ResourceID)
+	accountID := AccountID
+	stored := storedCdnConfig(config, &id, &accountID, nextVersion("", stringValue(config.Version)))
+	s.cdnConfigs[config.ResourceID] = stored
+
+	writeJSON(w, http.StatusCreated, stored)
+}
+
+// getCdnConfig handles GET /cdn-configs/{resourceId}
+func (s *Server) getCdnConfig(w http.ResponseWriter, r *http.Request) {
+	config, ok := s.findCdnConfig(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, config)
+}
+
+// getCdnConfigPart handles the GET requests for a part of a CDN configuration, e.g. GET /cdn-configs/{resourceId}/cdns
+func (s *Server) getCdnConfigPart(part func(cdnclient.CdnConfigurationResponse) any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		config, ok := s.findCdnConfig(w, r)
+		if !ok {
+			return
+		}
+
+		writeJSON(w, http.StatusOK, part(config))
+	}
+}
+
+// updateCdnConfig handles PUT /cdn-configs/{resourceId}
+func (s *Server) updateCdnConfig(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.findCdnConfig(w, r)
+	if !ok {
+		return
+	}
+
+	currentVersion := stringValue(existing.Version)
+	if !versionMatches(r, currentVersion) {
+		writeError(w, http.StatusPreconditionFailed, "Version mismatch")
+		return
+	}
+
+	var config cdnclient.CdnConfiguration
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if config.ResourceID != 0 && config.ResourceID != existing.ResourceID {
+		writeError(w, http.StatusBadRequest, "Resource ID in body does not match URL")
+		return
+	}
+	config.ResourceID = existing.ResourceID
+
+	stored := storedCdnConfig(config, existing.ID, existing.AccountID, nextVersion(currentVersion, stringValue(config.Version)))
+	s.cdnConfigs[config.ResourceID] = stored
+
+	writeJSON(w, http.StatusOK, stored)
+}
+
+// deleteCdnConfig handles DELETE /cdn-configs/{resourceId}
+func (s *Server) deleteCdnConfig(w http.ResponseWriter, r *http.Request) {
+	config, ok := s.findCdnConfig(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.cdnConfigs, config.ResourceID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findCdnConfig returns a copy of the CDN configuration addressed by a request, writing a 404 Not Found response if it does not exist
+func (s *Server) findCdnConfig(w http.ResponseWriter, r *http.Request) (cdnclient.CdnConfigurationResponse, bool) {
+	id, ok := resourceID(w, r)
+	if !ok {
+		return cdnclient.CdnConfigurationResponse{}, false
+	}
+
+	config, exists := s.cdnConfigs[id]
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("CDN configuration with ID %d not found", id))
+		return cdnclient.CdnConfigurationResponse{}, false
+	}
+
+	return clone(config), true
+}
+
+// storedCdnConfig builds the stored form of a created or updated CDN configuration
+func storedCdnConfig(config cdnclient.CdnConfiguration, id *string, accountID *int64, version string) cdnclient.CdnConfigurationResponse {
+	return cdnclient.CdnConfigurationResponse{
+		ID:                  id,
+		AccountID:           accountID,
+		ResourceID:          config.ResourceID,
+		ContentType:         config.ContentType,
+		Description:         config.Description,
+		Version:             &version,
+		LastUpdated:         lastUpdated(),
+		Cdns:                config.Cdns,
+		CdnEnablementMap:    config.CdnEnablementMap,
+		TrafficDistribution: config.TrafficDistribution,
+	}
+}
+
+// stringValue returns the value of an optional string, or "" if it is not set
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
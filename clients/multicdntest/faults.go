@@ -0,0 +1,117 @@
+package multicdntest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault describes a failure injected into the requests it matches, before they are authenticated or handled
+type Fault struct {
+	// Method restricts the fault to requests with this HTTP method. Empty matches every method.
+	Method string
+	// PathPrefix restricts the fault to requests whose path starts with this prefix. Empty matches every path.
+	PathPrefix string
+	// Count is the number of matching requests the fault applies to. Zero applies it to every matching request.
+	Count int
+
+	// Latency delays the request, or the injected response if there is one.
+	// The delay ends early if the client gives up on the request.
+	Latency time.Duration
+	// StatusCode, if set, is returned instead of handling the request
+	StatusCode int
+	// Header is added to the response with StatusCode, e.g. to set Retry-After
+	Header http.Header
+	// Body is the body of the response with StatusCode
+	Body string
+	// DropConnection closes the connection without writing a response
+	DropConnection bool
+}
+
+// injectedFault is a fault along with the number of requests it still applies to
+type injectedFault struct {
+	Fault
+	remaining int
+}
+
+// InjectFault adds a fault for subsequent requests. When several faults match a request,
+// the one injected first applies.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &injectedFault{Fault: fault, remaining: fault.Count})
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// nextFault returns the fault applying to a request, if any, using up one of its occurrences
+func (s *Server) nextFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.PathPrefix) {
+			continue
+		}
+
+		if fault.Count > 0 {
+			fault.remaining--
+			if fault.remaining == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+
+		return &fault.Fault
+	}
+
+	return nil
+}
+
+// injectFault applies the fault matching a request, if any, and reports whether the request was answered by it
+func (s *Server) injectFault(w http.ResponseWriter, r *http.Request) bool {
+	fault := s.nextFault(r)
+	if fault == nil {
+		return false
+	}
+
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	switch {
+	case fault.DropConnection:
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			panic("multicdntest: dropping connection: " + err.Error())
+		}
+		_ = conn.Close()
+		return true
+
+	case fault.StatusCode != 0:
+		for name, values := range fault.Header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(fault.StatusCode)
+		_, _ = w.Write([]byte(fault.Body))
+		return true
+	}
+
+	return false
+}
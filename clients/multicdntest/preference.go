@@ -0,0 +1,190 @@
+package multicdntest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+)
+
+// Preference returns a copy of the stored preference with the given resource ID
+func (s *Server) Preference(resourceID int64) (*preferenceclient.Preference, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	preference, exists := s.preferences[resourceID]
+	if !exists {
+		return nil, false
+	}
+
+	preference = clone(preference)
+	return &preference, true
+}
+
+// Preferences returns copies of all stored preferences, ordered by resource ID
+func (s *Server) Preferences() []preferenceclient.Preference {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedPreferences()
+}
+
+// SetPreference stores a preference as is, replacing any preference with the same resource ID.
+// Unlike requests to the API, it does not change the version or modification timestamp.
+func (s *Server) SetPreference(preference preferenceclient.Preference) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.preferences[preference.ResourceID] = clone(preference)
+}
+
+// DeletePreference removes a stored preference, reporting whether it existed
+func (s *Server) DeletePreference(resourceID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.preferences[resourceID]
+	delete(s.preferences, resourceID)
+	return exists
+}
+
+// sortedPreferences returns copies of all stored preferences ordered by resource ID, so pages are stable
+func (s *Server) sortedPreferences() []preferenceclient.Preference {
+	preferences := make([]preferenceclient.Preference, 0, len(s.preferences))
+	for _, preference := range s.preferences {
+		preferences = append(preferences, clone(preference))
+	}
+	slices.SortFunc(preferences, func(a, b preferenceclient.Preference) int {
+		return cmp.Compare(a.ResourceID, b.ResourceID)
+	})
+
+	return preferences
+}
+
+// listPreferences handles GET /preference
+func (s *Server) listPreferences(w http.ResponseWriter, r *http.Request) {
+	page, size, err := pageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	preferences, totalPages := pageOf(s.sortedPreferences(), page, size)
+	writeJSON(w, http.StatusOK, preferenceclient.PreferencePage{
+		PreferenceConfigs: preferences,
+		TotalElements:     len(s.preferences),
+		TotalPages:        totalPages,
+		PageNumber:        page,
+		PageSize:          size,
+		NumberOfElements:  len(preferences),
+		First:             page == 0,
+		Last:              page >= totalPages-1,
+		Empty:             len(preferences) == 0,
+	})
+}
+
+// createPreference handles POST /preference
+func (s *Server) createPreference(w http.ResponseWriter, r *http.Request) {
+	var preference preferenceclient.Preference
+	if err := json.NewDecoder(r.Body).Decode(&preference); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if preference.ResourceID <= 0 {
+		writeError(w, http.StatusBadRequest, "resource_id must be a positive integer")
+		return
+	}
+	if _, exists := s.preferences[preference.ResourceID]; exists {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Preference with ID %d already exists", preference.ResourceID))
+		return
+	}
+
+	preference.Version = nextVersion("", preference.Version)
+	preference.LastUpdated = lastUpdated()
+	s.preferences[preference.ResourceID] = preference
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// getPreference handles GET /preference/{resourceId}
+func (s *Server) getPreference(w http.ResponseWriter, r *http.Request) {
+	preference, ok := s.findPreference(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, preference)
+}
+
+// getPreferencePart handles the GET requests for a part of a preference, e.g. GET /preference/{resourceId}/performanceFiltering
+func (s *Server) getPreferencePart(part func(preferenceclient.Preference) any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		preference, ok := s.findPreference(w, r)
+		if !ok {
+			return
+		}
+
+		writeJSON(w, http.StatusOK, part(preference))
+	}
+}
+
+// updatePreference handles PUT /preference/{resourceId}
+func (s *Server) updatePreference(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.findPreference(w, r)
+	if !ok {
+		return
+	}
+
+	if !versionMatches(r, existing.Version) {
+		writeError(w, http.StatusPreconditionFailed, "Version mismatch")
+		return
+	}
+
+	var preference preferenceclient.Preference
+	if err := json.NewDecoder(r.Body).Decode(&preference); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if preference.ResourceID != existing.ResourceID {
+		writeError(w, http.StatusBadRequest, "Resource ID in body does not match URL")
+		return
+	}
+
+	preference.Version = nextVersion(existing.Version, preference.Version)
+	preference.LastUpdated = lastUpdated()
+	s.preferences[preference.ResourceID] = preference
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// deletePreference handles DELETE /preference/{resourceId}
+func (s *Server) deletePreference(w http.ResponseWriter, r *http.Request) {
+	preference, ok := s.findPreference(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.preferences, preference.ResourceID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findPreference returns a copy of the preference addressed by a request, writing a 404 Not Found response if it does not exist
+func (s *Server) findPreference(w http.ResponseWriter, r *http.Request) (preferenceclient.Preference, bool) {
+	id, ok := resourceID(w, r)
+	if !ok {
+		return preferenceclient.Preference{}, false
+	}
+
+	preference, exists := s.preferences[id]
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Preference with ID %d not found", id))
+		return preferenceclient.Preference{}, false
+	}
+
+	return clone(preference), true
+}
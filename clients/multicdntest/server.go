@@ -0,0 +1,327 @@
+// Package multicdntest provides an in-process fake of the MultiCDN API for tests.
+//
+// The fake keeps CDN configurations and preferences in memory, verifies the HMAC auth token of every
+// request, sets versions and modification timestamps the way the API does, and can inject faults such
+// as latency, error responses and dropped connections.
+package multicdntest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/cdnclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+)
+
+const (
+	// DefaultAPIKey is the API key accepted by a server created without WithCredentials
+	DefaultAPIKey = "test-key"
+	// DefaultAPISecret is the API secret accepted by a server created without WithCredentials
+	DefaultAPISecret = "test-secret"
+	// AccountID is the account every CDN configuration created through the server belongs to
+	AccountID int64 = 1001
+	// DefaultPageSize is the page size of list requests without a size parameter
+	DefaultPageSize = 50
+	// MaxClockSkew is how far the timestamp of an auth token may be from the server's clock
+	MaxClockSkew = 5 * time.Minute
+)
+
+// Server is a stateful fake of the MultiCDN API served over HTTP.
+// It is safe for concurrent use, and the stored resources can be read and changed by tests at any time,
+// e.g. to simulate changes made outside Terraform.
+type Server struct {
+	// URL is the base URL of the server, e.g. "http://127.0.0.1:1234"
+	URL string
+
+	apiKey    string
+	apiSecret string
+	server    *httptest.Server
+
+	mu          sync.Mutex
+	cdnConfigs  map[int64]cdnclient.CdnConfigurationResponse
+	preferences map[int64]preferenceclient.Preference
+	faults      []*injectedFault
+	requests    []Request
+}
+
+// Option allows for customization of the server
+type Option func(*Server)
+
+// WithCredentials sets the API key and secret the server accepts, instead of DefaultAPIKey and DefaultAPISecret
+func WithCredentials(apiKey, apiSecret string) Option {
+	return func(s *Server) {
+		s.apiKey = apiKey
+		s.apiSecret = apiSecret
+	}
+}
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string // Path including the query string, e.g. "/cdn-configs?page=0&size=50"
+	Header http.Header
+	Body   []byte
+}
+
+// NewServer starts a server with no stored resources. Callers should call Close when done.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		apiKey:      DefaultAPIKey,
+		apiSecret:   DefaultAPISecret,
+		cdnConfigs:  make(map[int64]cdnclient.CdnConfigurationResponse),
+		preferences: make(map[int64]preferenceclient.Preference),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.server = httptest.NewServer(s.handler())
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests have completed
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// APIKey returns the API key the server accepts
+func (s *Server) APIKey() string {
+	return s.apiKey
+}
+
+// APISecret returns the API secret the server accepts
+func (s *Server) APISecret() string {
+	return s.apiSecret
+}
+
+// NewClient returns an HTTP client for the server using the accepted credentials
+func (s *Server) NewClient(options ...httpclient.ClientOption) *httpclient.Client {
+	return httpclient.New(s.URL, s.apiKey, s.apiSecret, options...)
+}
+
+// Requests returns the requests received so far, in the order they arrived
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// handler routes requests to the fake API endpoints after recording them, injecting faults and
+// verifying the auth token
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /cdn-configs", s.locked(s.listCdnConfigs))
+	mux.HandleFunc("POST /cdn-configs", s.locked(s.createCdnConfig))
+	mux.HandleFunc("GET /cdn-configs/{resourceId}", s.locked(s.getCdnConfig))
+	mux.HandleFunc("PUT /cdn-configs/{resourceId}", s.locked(s.updateCdnConfig))
+	mux.HandleFunc("DELETE /cdn-configs/{resourceId}", s.locked(s.deleteCdnConfig))
+	mux.HandleFunc("GET /cdn-configs/{resourceId}/cdns", s.locked(s.getCdnConfigPart(func(c cdnclient.CdnConfigurationResponse) any { return c.Cdns })))
+	mux.HandleFunc("GET /cdn-configs/{resourceId}/enablement", s.locked(s.getCdnConfigPart(func(c cdnclient.CdnConfigurationResponse) any { return c.CdnEnablementMap })))
+	mux.HandleFunc("GET /cdn-configs/{resourceId}/trafficDistribution", s.locked(s.getCdnConfigPart(func(c cdnclient.CdnConfigurationResponse) any { return c.TrafficDistribution })))
+
+	mux.HandleFunc("GET /preference", s.locked(s.listPreferences))
+	mux.HandleFunc("POST /preference", s.locked(s.createPreference))
+	mux.HandleFunc("GET /preference/{resourceId}", s.locked(s.getPreference))
+	mux.HandleFunc("PUT /preference/{resourceId}", s.locked(s.updatePreference))
+	mux.HandleFunc("DELETE /preference/{resourceId}", s.locked(s.deletePreference))
+	mux.HandleFunc("GET /preference/{resourceId}/availabilityThresholds", s.locked(s.getPreferencePart(func(p preferenceclient.Preference) any { return p.AvailabilityThresholds })))
+	mux.HandleFunc("GET /preference/{resourceId}/performanceFiltering", s.locked(s.getPreferencePart(func(p preferenceclient.Preference) any { return p.PerformanceFiltering })))
+	mux.HandleFunc("GET /preference/{resourceId}/enabledSubdivisionCountries", s.locked(s.getPreferencePart(func(p preferenceclient.Preference) any { return p.EnabledSubdivisionCountries })))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Route not found")
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Unable to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		s.record(r, body)
+
+		if s.injectFault(w, r) {
+			return
+		}
+
+		if err := s.verifyToken(r.Header.Get("x-cns-security-token")); err != nil {
+			writeError(w, http.StatusUnauthorized, "Unauthorized: "+err.Error())
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// locked serializes access to the stored resources
+func (s *Server) locked(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		handler(w, r)
+	}
+}
+
+// record adds a request to the request log
+func (s *Server) record(r *http.Request, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.RequestURI(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+}
+
+// verifyToken checks an auth token of the form "apiKey:signature:timestamp", where the signature is
+// the HMAC of the timestamp in milliseconds keyed with the API secret
+func (s *Server) verifyToken(token string) error {
+	if token == "" {
+		return errors.New("missing security token")
+	}
+
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 {
+		return errors.New("malformed security token")
+	}
+	apiKey, signature, timestamp := parts[0], parts[1], parts[2]
+
+	if subtle.ConstantTimeCompare([]byte(apiKey), []byte(s.apiKey)) != 1 {
+		return errors.New("unknown API key")
+	}
+	if !hmac.Equal([]byte(signature), []byte(httpclient.ComputeHMAC(s.apiSecret, timestamp))) {
+		return errors.New("invalid signature")
+	}
+
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid timestamp")
+	}
+	if skew := time.Since(time.UnixMilli(millis)); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return errors.New("expired security token")
+	}
+
+	return nil
+}
+
+// resourceID parses the resource ID path parameter, writing a 400 Bad Request response if it is invalid
+func resourceID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("resourceId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid resource ID %q", r.PathValue("resourceId")))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// versionMatches reports whether the If-Match header of a conditional request, if any, names the given version
+func versionMatches(r *http.Request, version string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	return ifMatch == "" || ifMatch == "*" || ifMatch == strconv.Quote(version)
+}
+
+// nextVersion returns the version stored on create or update. A version sent by the client is kept as is,
+// otherwise the last numeric component of the current version is incremented, e.g. "1.0" becomes "1.1".
+func nextVersion(current, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	if current == "" {
+		return "1"
+	}
+
+	prefix, last := "", current
+	if i := strings.LastIndex(current, "."); i >= 0 {
+		prefix, last = current[:i+1], current[i+1:]
+	}
+
+	n, err := strconv.Atoi(last)
+	if err != nil {
+		return current + ".1"
+	}
+
+	return prefix + strconv.Itoa(n+1)
+}
+
+// lastUpdated returns the modification timestamp set on every create and update
+func lastUpdated() *time.Time {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &now
+}
+
+// pageParams parses the page and size query parameters of a list request
+func pageParams(r *http.Request) (page, size int, err error) {
+	page, size = 0, DefaultPageSize
+
+	if value := r.URL.Query().Get("page"); value != "" {
+		if page, err = strconv.Atoi(value); err != nil || page < 0 {
+			return 0, 0, fmt.Errorf("invalid page %q", value)
+		}
+	}
+	if value := r.URL.Query().Get("size"); value != "" {
+		if size, err = strconv.Atoi(value); err != nil || size <= 0 {
+			return 0, 0, fmt.Errorf("invalid size %q", value)
+		}
+	}
+
+	return page, size, nil
+}
+
+// pageOf returns the items on a page, along with the total number of pages
+func pageOf[T any](items []T, page, size int) ([]T, int) {
+	totalPages := (len(items) + size - 1) / size
+	start := min(page*size, len(items))
+	end := min(start+size, len(items))
+
+	return slices.Clone(items[start:end]), totalPages
+}
+
+// clone returns a deep copy of a stored resource, so callers cannot change the store through shared maps and slices
+func clone[T any](value T) T {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("multicdntest: cloning %T: %v", value, err))
+	}
+
+	var copied T
+	if err := json.Unmarshal(data, &copied); err != nil {
+		panic(fmt.Sprintf("multicdntest: cloning %T: %v", value, err))
+	}
+
+	return copied
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error response in the format of the API
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{"error": message})
+}
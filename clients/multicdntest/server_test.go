@@ -0,0 +1,232 @@
+package multicdntest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/cdnclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+)
+
+// noRetry disables retries so injected faults surface directly
+var noRetry = httpclient.WithRetryPolicy(httpclient.RetryPolicy{MaxAttempts: 1})
+
+func TestCdnConfigLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := cdnclient.New(server.NewClient(noRetry))
+	description := "Initial"
+
+	created, err := client.CreateCdnConfig(ctx, &cdnclient.CdnConfiguration{
+		ResourceID:  123,
+		Description: &description,
+		Cdns:        []cdnclient.CdnEntry{{CdnName: "cdn1", FQDN: "cdn1.example.com", ClientCdnID: "CDN1"}},
+	})
+	if err != nil {
+		t.Fatalf("Error creating CDN configuration: %v", err)
+	}
+	if created.ID == nil || created.AccountID == nil || *created.AccountID != AccountID || created.LastUpdated == nil {
+		t.Errorf("Expected the API to set the ID, account ID and last updated timestamp, got %+v", created)
+	}
+	if created.Version == nil || *created.Version != "1" {
+		t.Errorf("Expected version 1 after create, got %v", created.Version)
+	}
+
+	if _, err := client.CreateCdnConfig(ctx, &cdnclient.CdnConfiguration{ResourceID: 123}); !response.IsConflict(err) {
+		t.Errorf("Expected a conflict when creating an existing configuration, got %v", err)
+	}
+
+	entries, err := client.GetCdnEntries(ctx, 123)
+	if err != nil || len(entries) != 1 || entries[0].CdnName != "cdn1" {
+		t.Errorf("Expected the CDN entries, got %v (error: %v)", entries, err)
+	}
+
+	// An update without a version bumps it, and a stale If-Match is rejected
+	description = "Updated"
+	updated, err := client.UpdateCdnConfig(ctx, 123, &cdnclient.CdnConfiguration{ResourceID: 123, Description: &description}, httpclient.WithIfMatch("1"))
+	if err != nil {
+		t.Fatalf("Error updating CDN configuration: %v", err)
+	}
+	if *updated.Version != "2" || *updated.ID != *created.ID {
+		t.Errorf("Expected version 2 and an unchanged ID after update, got %+v", updated)
+	}
+	if _, err := client.UpdateCdnConfig(ctx, 123, &cdnclient.CdnConfiguration{ResourceID: 123}, httpclient.WithIfMatch("1")); !response.IsPreconditionFailed(err) {
+		t.Errorf("Expected a precondition failure for a stale version, got %v", err)
+	}
+
+	if stored, ok := server.CdnConfig(123); !ok || *stored.Description != "Updated" {
+		t.Errorf("Expected the update to be stored, got %+v", stored)
+	}
+
+	if err := client.DeleteCdnConfig(ctx, 123); err != nil {
+		t.Fatalf("Error deleting CDN configuration: %v", err)
+	}
+	if _, err := client.GetCdnConfig(ctx, 123); !response.IsNotFound(err) {
+		t.Errorf("Expected the configuration to be gone, got %v", err)
+	}
+}
+
+func TestPreferenceLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := preferenceclient.New(server.NewClient(noRetry))
+
+	err := client.CreatePreference(ctx, &preferenceclient.Preference{
+		ResourceID:             123,
+		Version:                "1.0",
+		AvailabilityThresholds: preferenceclient.AvailabilityThresholds{World: 95},
+	})
+	if err != nil {
+		t.Fatalf("Error creating preference: %v", err)
+	}
+
+	thresholds, err := client.GetAvailabilityThresholds(ctx, 123)
+	if err != nil || thresholds.World != 95 {
+		t.Errorf("Expected the availability thresholds, got %+v (error: %v)", thresholds, err)
+	}
+
+	// A version sent by the client is kept, otherwise it is bumped
+	if err := client.UpdatePreference(ctx, 123, &preferenceclient.Preference{ResourceID: 123}, httpclient.WithIfMatch("1.0")); err != nil {
+		t.Fatalf("Error updating preference: %v", err)
+	}
+	preference, err := client.GetPreference(ctx, 123)
+	if err != nil || preference.Version != "1.1" || preference.LastUpdated == nil {
+		t.Errorf("Expected version 1.1 and a last updated timestamp, got %+v (error: %v)", preference, err)
+	}
+
+	if err := client.UpdatePreference(ctx, 124, &preferenceclient.Preference{ResourceID: 124}); !response.IsNotFound(err) {
+		t.Errorf("Expected updating a missing preference to fail, got %v", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	for id := int64(1); id <= 5; id++ {
+		server.SetCdnConfig(cdnclient.CdnConfigurationResponse{ResourceID: id})
+		server.SetPreference(preferenceclient.Preference{ResourceID: id})
+	}
+
+	ctx := context.Background()
+	httpClient := server.NewClient()
+
+	configs, err := cdnclient.New(httpClient).GetAllCdnConfigs(ctx, 2)
+	if err != nil || len(configs) != 5 || configs[4].ResourceID != 5 {
+		t.Errorf("Expected all 5 CDN configurations in order, got %v (error: %v)", configs, err)
+	}
+
+	page, err := preferenceclient.New(httpClient).GetPreferencesPage(ctx, 2, 2)
+	if err != nil {
+		t.Fatalf("Error getting preferences page: %v", err)
+	}
+	if page.NumberOfElements != 1 || page.TotalPages != 3 || !page.Last || page.PreferenceConfigs[0].ResourceID != 5 {
+		t.Errorf("Expected the last page to hold preference 5, got %+v", page)
+	}
+
+	if _, err := cdnclient.New(httpClient).GetCdnConfigsPage(ctx, -1, 2); !response.IsBadRequest(err) {
+		t.Errorf("Expected a negative page to be rejected, got %v", err)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	server := NewServer(WithCredentials("key", "secret"))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		apiKey    string
+		apiSecret string
+		expectErr bool
+	}{
+		{name: "valid", apiKey: "key", apiSecret: "secret"},
+		{name: "unknown_key", apiKey: "other", apiSecret: "secret", expectErr: true},
+		{name: "wrong_secret", apiKey: "key", apiSecret: "other", expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := preferenceclient.New(httpclient.New(server.URL, tc.apiKey, tc.apiSecret, noRetry))
+			_, err := client.GetPreferencesPage(context.Background(), 0, 10)
+			if tc.expectErr != response.IsUnauthorized(err) || (!tc.expectErr && err != nil) {
+				t.Errorf("Expected unauthorized: %v, got error: %v", tc.expectErr, err)
+			}
+		})
+	}
+
+	resp, err := http.Get(server.URL + "/preference")
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected a request without a token to be rejected, got status %d", resp.StatusCode)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.SetPreference(preferenceclient.Preference{ResourceID: 123})
+	ctx := context.Background()
+
+	// Two throttled responses are retried, and the fault is used up afterwards
+	server.InjectFault(Fault{
+		Method:     http.MethodGet,
+		PathPrefix: "/preference/123",
+		Count:      2,
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"0"}},
+	})
+	client := preferenceclient.New(server.NewClient(httpclient.WithRetryPolicy(httpclient.RetryPolicy{MaxAttempts: 3})))
+	if _, err := client.GetPreference(ctx, 123); err != nil {
+		t.Errorf("Expected the request to succeed after retries, got %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", len(requests))
+	}
+
+	server.InjectFault(Fault{DropConnection: true})
+	client = preferenceclient.New(server.NewClient(noRetry))
+	if _, err := client.GetPreference(ctx, 123); err == nil || !strings.Contains(err.Error(), "error executing request") {
+		t.Errorf("Expected a transport error for a dropped connection, got %v", err)
+	}
+
+	server.ClearFaults()
+	server.InjectFault(Fault{Latency: time.Minute})
+	client = preferenceclient.New(server.NewClient(noRetry, httpclient.WithRequestTimeout(50*time.Millisecond)))
+	if _, err := client.GetPreference(ctx, 123); err == nil || !strings.Contains(err.Error(), "no response within the request timeout") {
+		t.Errorf("Expected a request timeout, got %v", err)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		current   string
+		requested string
+		expected  string
+	}{
+		{current: "", requested: "", expected: "1"},
+		{current: "", requested: "1.0", expected: "1.0"},
+		{current: "1", requested: "", expected: "2"},
+		{current: "1.9", requested: "", expected: "1.10"},
+		{current: "beta", requested: "", expected: "beta.1"},
+		{current: "2", requested: "5.0", expected: "5.0"},
+	}
+
+	for _, tc := range tests {
+		if got := nextVersion(tc.current, tc.requested); got != tc.expected {
+			t.Errorf("nextVersion(%q, %q) = %q, expected %q", tc.current, tc.requested, got, tc.expected)
+		}
+	}
+}
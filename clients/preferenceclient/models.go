@@ -29,14 +29,14 @@ type Preference struct {
 
 // AvailabilityThresholds represents the thresholds for availability
 type AvailabilityThresholds struct {
-	World      int64                         `json:"world,omitempty"` // range 0-100
+	World      float64                       `json:"world,omitempty"` // range 0-100, e.g. 99.5
 	Continents map[string]ContinentThreshold `json:"continents,omitempty"`
 }
 
 // ContinentThreshold represents availability thresholds for a continent
 type ContinentThreshold struct {
-	Default   int64            `json:"default,omitempty"`   // range 0-100
-	Countries map[string]int64 `json:"countries,omitempty"` // range 0-100
+	Default   float64            `json:"default,omitempty"`   // range 0-100
+	Countries map[string]float64 `json:"countries,omitempty"` // range 0-100
 }
 
 // PerformanceFiltering represents the performance filtering configuration
@@ -10,6 +10,7 @@ import (
 )
 
 // Client represents the CDN Preference API client
+// Errors reported by the API are returned as *response.APIError
 type Client struct {
 	*httpclient.Client // HTTP client for making requests
 }
@@ -23,20 +24,20 @@ func New(httpclient *httpclient.Client) *Client {
 	return client
 }
 
-// GetPreferencesPage retrieves CDN configurations for the authenticated account with pagination
-func (c *Client) GetPreferencesPage(ctx context.Context, pageNumber, pageSize int) ([]PreferencePage, error) {
+// GetPreferencesPage retrieves CDN preference configurations for the authenticated account with pagination
+func (c *Client) GetPreferencesPage(ctx context.Context, pageNumber, pageSize int) (*PreferencePage, error) {
 	path := fmt.Sprintf("/preference?page=%d&size=%d", pageNumber, pageSize)
 	resp, err := c.MakeRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var preferences []PreferencePage
-	if err := response.Parse(resp, &preferences); err != nil {
+	var preferencePage PreferencePage
+	if err := response.Parse(resp, &preferencePage); err != nil {
 		return nil, err
 	}
 
-	return preferences, nil
+	return &preferencePage, nil
 }
 
 // CreatePreference creates a new configuration preference
@@ -65,10 +66,11 @@ func (c *Client) GetPreference(ctx context.Context, resourceID int64) (*Preferen
 	return &preference, nil
 }
 
-// UpdatePreference updates an existing configuration by resourceId
-func (c *Client) UpdatePreference(ctx context.Context, resourceID int64, preference *Preference) error {
+// UpdatePreference updates an existing configuration by resourceId.
+// Pass httpclient.WithIfMatch to only update the configuration if it still has the expected version.
+func (c *Client) UpdatePreference(ctx context.Context, resourceID int64, preference *Preference, options ...httpclient.RequestOption) error {
 	path := fmt.Sprintf("/preference/%d", resourceID)
-	resp, err := c.MakeRequest(ctx, http.MethodPut, path, preference)
+	resp, err := c.MakeRequest(ctx, http.MethodPut, path, preference, options...)
 	if err != nil {
 		return err
 	}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
 )
 
 func TestGetPreferencesPage(t *testing.T) {
@@ -26,7 +27,7 @@ func TestGetPreferencesPage(t *testing.T) {
 			pageNumber: 0,
 			pageSize:   10,
 			statusCode: http.StatusOK,
-			response: `[{
+			response: `{
 				"preferenceConfigs": [
 					{
 						"resourceId": 123,
@@ -68,7 +69,7 @@ func TestGetPreferencesPage(t *testing.T) {
 				"first": true,
 				"last": true,
 				"empty": false
-			}]`,
+			}`,
 			expectErr: false,
 		},
 		{
@@ -111,7 +112,7 @@ func TestGetPreferencesPage(t *testing.T) {
 
 			// Call the method
 			ctx := context.Background()
-			preferences, err := client.GetPreferencesPage(ctx, tc.pageNumber, tc.pageSize)
+			page, err := client.GetPreferencesPage(ctx, tc.pageNumber, tc.pageSize)
 
 			// Validate results
 			if tc.expectErr && err == nil {
@@ -122,19 +123,20 @@ func TestGetPreferencesPage(t *testing.T) {
 
 			if !tc.expectErr && err == nil {
 				// Validate response data
-				if len(preferences) == 0 {
-					t.Error("Expected preferences but got none")
-				} else {
-					pref := preferences[0]
-					if pref.TotalElements != 1 {
-						t.Errorf("Expected 1 total element, got %d", pref.TotalElements)
-					}
-					if len(pref.PreferenceConfigs) != 1 {
-						t.Errorf("Expected 1 preference config, got %d", len(pref.PreferenceConfigs))
-					}
-					if pref.PreferenceConfigs[0].ResourceID != 123 {
-						t.Errorf("Expected resource ID 123, got %d", pref.PreferenceConfigs[0].ResourceID)
-					}
+				if page == nil {
+					t.Fatal("Expected a preference page but got nil")
+				}
+				if page.TotalElements != 1 {
+					t.Errorf("Expected 1 total element, got %d", page.TotalElements)
+				}
+				if len(page.PreferenceConfigs) != 1 {
+					t.Fatalf("Expected 1 preference config, got %d", len(page.PreferenceConfigs))
+				}
+				if page.PreferenceConfigs[0].ResourceID != 123 {
+					t.Errorf("Expected resource ID 123, got %d", page.PreferenceConfigs[0].ResourceID)
+				}
+				if !page.Last {
+					t.Error("Expected the page to be marked as last")
 				}
 			}
 		})
@@ -160,8 +162,8 @@ func TestCreatePreference(t *testing.T) {
 					Continents: map[string]ContinentThreshold{
 						"NA": {
 							Default: 98,
-							Countries: map[string]int64{
-								"US": 99,
+							Countries: map[string]float64{
+								"US": 99.9,
 							},
 						},
 					},
@@ -336,6 +338,10 @@ func TestGetPreference(t *testing.T) {
 				t.Errorf("Expected no error but got: %v", err)
 			}
 
+			if tc.statusCode == http.StatusNotFound && !response.IsNotFound(err) {
+				t.Errorf("Expected a not found API error, got: %v", err)
+			}
+
 			if !tc.expectErr && preference != nil {
 				// Validate response data
 				if preference.ResourceID != tc.resourceID {
@@ -355,6 +361,7 @@ func TestUpdatePreference(t *testing.T) {
 		name       string
 		resourceID int64
 		preference Preference
+		ifMatch    string
 		statusCode int
 		expectErr  bool
 	}{
@@ -388,6 +395,16 @@ func TestUpdatePreference(t *testing.T) {
 			statusCode: http.StatusNotFound,
 			expectErr:  true,
 		},
+		{
+			name:       "version_mismatch",
+			resourceID: 123,
+			preference: Preference{
+				ResourceID: 123,
+			},
+			ifMatch:    "1.0",
+			statusCode: http.StatusPreconditionFailed,
+			expectErr:  true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -403,6 +420,15 @@ func TestUpdatePreference(t *testing.T) {
 					t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
 				}
 
+				// Check the expected version is only sent when requested
+				expectedIfMatch := ""
+				if tc.ifMatch != "" {
+					expectedIfMatch = strconv.Quote(tc.ifMatch)
+				}
+				if got := r.Header.Get("If-Match"); got != expectedIfMatch {
+					t.Errorf("Expected If-Match %q, got %q", expectedIfMatch, got)
+				}
+
 				// Decode request body
 				var req Preference
 				decoder := json.NewDecoder(r.Body)
@@ -425,7 +451,11 @@ func TestUpdatePreference(t *testing.T) {
 
 			// Call the method
 			ctx := context.Background()
-			err := client.UpdatePreference(ctx, tc.resourceID, &tc.preference)
+			var options []httpclient.RequestOption
+			if tc.ifMatch != "" {
+				options = append(options, httpclient.WithIfMatch(tc.ifMatch))
+			}
+			err := client.UpdatePreference(ctx, tc.resourceID, &tc.preference, options...)
 
 			// Validate results
 			if tc.expectErr && err == nil {
@@ -433,6 +463,9 @@ func TestUpdatePreference(t *testing.T) {
 			} else if !tc.expectErr && err != nil {
 				t.Errorf("Expected no error but got: %v", err)
 			}
+			if tc.statusCode == http.StatusPreconditionFailed && !response.IsPreconditionFailed(err) {
+				t.Errorf("Expected a precondition failed API error, got: %v", err)
+			}
 		})
 	}
 }
@@ -513,7 +546,7 @@ func TestGetAvailabilityThresholds(t *testing.T) {
 					"NA": {
 						"default": 98,
 						"countries": {
-							"US": 99,
+							"US": 99.5,
 							"CA": 97
 						}
 					}
@@ -567,15 +600,15 @@ func TestGetAvailabilityThresholds(t *testing.T) {
 			if !tc.expectErr && thresholds != nil {
 				// Validate response data
 				if thresholds.World != 95.0 {
-					t.Errorf("Expected world threshold 95, got %d", thresholds.World)
+					t.Errorf("Expected world threshold 95, got %g", thresholds.World)
 				}
 				if continent, ok := thresholds.Continents["NA"]; ok {
 					if continent.Default != 98.0 {
-						t.Errorf("Expected NA default threshold 98, got %d", continent.Default)
+						t.Errorf("Expected NA default threshold 98, got %g", continent.Default)
 					}
 					if country, ok := continent.Countries["US"]; ok {
-						if country != 99 {
-							t.Errorf("Expected US threshold 99, got %d", country)
+						if country != 99.5 {
+							t.Errorf("Expected US threshold 99.5, got %g", country)
 						}
 					} else {
 						t.Error("Expected US country threshold but found none")
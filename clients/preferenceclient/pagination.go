@@ -0,0 +1,53 @@
+package preferenceclient
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the page size used when walking all preference configurations
+const DefaultPageSize = 50
+
+// PreferencePages returns an iterator over all pages of preference configurations, starting at the first page.
+// Iteration stops after the page marked as last or the last of the total number of pages, after an empty page,
+// or after the first error, which is yielded together with a nil page.
+func (c *Client) PreferencePages(ctx context.Context, pageSize int) iter.Seq2[*PreferencePage, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(*PreferencePage, error) bool) {
+		for pageNumber := 0; ; pageNumber++ {
+			page, err := c.GetPreferencesPage(ctx, pageNumber, pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(page, nil) {
+				return
+			}
+
+			// Guard against servers that never mark a page as last
+			if page.Last || page.Empty || len(page.PreferenceConfigs) == 0 {
+				return
+			}
+			if page.TotalPages > 0 && pageNumber+1 >= page.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// GetAllPreferences retrieves every preference configuration for the authenticated account by walking all pages
+func (c *Client) GetAllPreferences(ctx context.Context, pageSize int) ([]Preference, error) {
+	var preferences []Preference
+	for page, err := range c.PreferencePages(ctx, pageSize) {
+		if err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, page.PreferenceConfigs...)
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,203 @@
+package preferenceclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// setupPagedMockServer creates a test server serving totalPreferences preference configurations in pages
+func setupPagedMockServer(t *testing.T, totalPreferences int, requestedPages *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/preference" || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		*requestedPages = append(*requestedPages, page)
+
+		if page == 99 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"Internal server error"}`))
+			return
+		}
+
+		totalPages := (totalPreferences + size - 1) / size
+		start := min(page*size, totalPreferences)
+		end := min(start+size, totalPreferences)
+
+		preferences := make([]Preference, 0, end-start)
+		for i := start; i < end; i++ {
+			preferences = append(preferences, Preference{ResourceID: int64(i + 1)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(PreferencePage{
+			PreferenceConfigs: preferences,
+			TotalElements:     totalPreferences,
+			TotalPages:        totalPages,
+			PageNumber:        page,
+			PageSize:          size,
+			NumberOfElements:  len(preferences),
+			First:             page == 0,
+			Last:              page >= totalPages-1,
+			Empty:             len(preferences) == 0,
+		}); err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+}
+
+func TestGetAllPreferences(t *testing.T) {
+	tests := []struct {
+		name             string
+		totalPreferences int
+		pageSize         int
+		expectedPages    []int
+	}{
+		{
+			name:             "multiple_pages",
+			totalPreferences: 7,
+			pageSize:         3,
+			expectedPages:    []int{0, 1, 2},
+		},
+		{
+			name:             "exact_page_boundary",
+			totalPreferences: 6,
+			pageSize:         3,
+			expectedPages:    []int{0, 1},
+		},
+		{
+			name:             "single_page",
+			totalPreferences: 2,
+			pageSize:         10,
+			expectedPages:    []int{0},
+		},
+		{
+			name:             "no_preferences",
+			totalPreferences: 0,
+			pageSize:         10,
+			expectedPages:    []int{0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestedPages []int
+			server := setupPagedMockServer(t, tc.totalPreferences, &requestedPages)
+			defer server.Close()
+
+			client := givenPreferenceClient(server.URL)
+
+			preferences, err := client.GetAllPreferences(context.Background(), tc.pageSize)
+			if err != nil {
+				t.Fatalf("GetAllPreferences() error = %v", err)
+			}
+
+			if len(preferences) != tc.totalPreferences {
+				t.Errorf("GetAllPreferences() expected %d preferences, got %d", tc.totalPreferences, len(preferences))
+			}
+			for i, preference := range preferences {
+				if preference.ResourceID != int64(i+1) {
+					t.Errorf("GetAllPreferences() expected resource ID %d at index %d, got %d", i+1, i, preference.ResourceID)
+				}
+			}
+
+			if len(requestedPages) != len(tc.expectedPages) {
+				t.Fatalf("Expected pages %v to be requested, got %v", tc.expectedPages, requestedPages)
+			}
+			for i, page := range tc.expectedPages {
+				if requestedPages[i] != page {
+					t.Errorf("Expected pages %v to be requested, got %v", tc.expectedPages, requestedPages)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestPreferencePagesStopsOnError(t *testing.T) {
+	var requestedPages []int
+	server := setupPagedMockServer(t, 1000, &requestedPages)
+	defer server.Close()
+
+	client := givenPreferenceClient(server.URL)
+
+	var pages int
+	var lastErr error
+	// The mock server fails on page 99
+	for _, err := range client.PreferencePages(context.Background(), 10) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		pages++
+	}
+
+	if lastErr == nil {
+		t.Error("PreferencePages() expected an error, got none")
+	}
+	if pages != 99 {
+		t.Errorf("PreferencePages() expected 99 pages before the error, got %d", pages)
+	}
+}
+
+func TestPreferencePagesStopsEarly(t *testing.T) {
+	var requestedPages []int
+	server := setupPagedMockServer(t, 100, &requestedPages)
+	defer server.Close()
+
+	client := givenPreferenceClient(server.URL)
+
+	for page, err := range client.PreferencePages(context.Background(), 10) {
+		if err != nil {
+			t.Fatalf("PreferencePages() error = %v", err)
+		}
+		if page.PageNumber == 1 {
+			break
+		}
+	}
+
+	if len(requestedPages) != 2 {
+		t.Errorf("Expected 2 pages to be requested after breaking early, got %d", len(requestedPages))
+	}
+}
+
+func TestPreferencePagesStopsAtTotalPages(t *testing.T) {
+	var requests int
+	// The server returns full pages without ever marking one as last
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(PreferencePage{
+			PreferenceConfigs: []Preference{{ResourceID: int64(page + 1)}},
+			TotalPages:        3,
+			PageNumber:        page,
+		}); err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var pages int
+	for _, err := range givenPreferenceClient(server.URL).PreferencePages(context.Background(), 1) {
+		if err != nil {
+			t.Fatalf("PreferencePages() error = %v", err)
+		}
+		pages++
+		if pages > 3 {
+			break
+		}
+	}
+
+	if pages != 3 || requests != 3 {
+		t.Errorf("PreferencePages() expected to stop after 3 pages, got %d pages and %d requests", pages, requests)
+	}
+}
@@ -56,7 +56,7 @@ resource "multicdn_cdn_config" "example_website" {
     world_default = ["AK54321", "FY67890", "CF12345"]
     asn_overrides = {
       "AS12345" = ["AK54321", "CF12345"]
-      "AS67890" = ["FY67890"]
+      "AS36890" = ["FY67890"]
     }
     continents = {
       "NA" = {
@@ -152,7 +152,11 @@ resource "multicdn_preference_config" "example_website_preferences" {
         countries = [
           "US",
           "CA",
-          "UK",
+        ]
+      },
+      "EU" = {
+        countries = [
+          "GB",
         ]
       },
     }
@@ -0,0 +1,74 @@
+// Package asn parses and classifies autonomous system numbers used as asn_overrides keys.
+package asn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is returned for values that are not an ASN in asplain notation
+var ErrInvalid = errors.New("invalid ASN")
+
+// Parse parses an ASN in asplain notation with an optional, case-insensitive "AS" prefix,
+// e.g. "AS12345", "as12345" or "12345". Values outside the 32-bit range are rejected.
+func Parse(s string) (uint32, error) {
+	digits := s
+	if len(digits) >= 2 && strings.EqualFold(digits[:2], "AS") {
+		digits = digits[2:]
+	}
+
+	// Only digits are accepted, which rules out signs, spaces and asdot notation
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, fmt.Errorf("%w %q: expected a number optionally prefixed with AS, e.g. AS12345", ErrInvalid, s)
+	}
+
+	n, err := strconv.ParseUint(digits, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: outside the 32-bit ASN range 0-4294967295", ErrInvalid, s)
+	}
+
+	return uint32(n), nil
+}
+
+// Format returns the canonical form of an ASN used by the API, the plain decimal number
+func Format(n uint32) string {
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+// Normalize parses s and returns its canonical form, so "AS13335" and "13335" both become "13335"
+func Normalize(s string) (string, error) {
+	n, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return Format(n), nil
+}
+
+// Reserved returns a description of the IANA reserved, documentation or private-use range
+// the ASN belongs to, or an empty string for ASNs that may be used on the public internet
+func Reserved(n uint32) string {
+	switch {
+	case n == 0:
+		return "reserved (RFC 7607)"
+	case n == 23456:
+		return "reserved for AS_TRANS (RFC 6793)"
+	case n >= 64496 && n <= 64511:
+		return "reserved for documentation (RFC 5398)"
+	case n >= 64512 && n <= 65534:
+		return "reserved for private use (RFC 6996)"
+	case n == 65535:
+		return "reserved (RFC 7300)"
+	case n >= 65536 && n <= 65551:
+		return "reserved for documentation (RFC 5398)"
+	case n >= 65552 && n <= 131071:
+		return "reserved by IANA"
+	case n >= 4200000000 && n <= 4294967294:
+		return "reserved for private use (RFC 6996)"
+	case n == 4294967295:
+		return "reserved (RFC 7300)"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,90 @@
+package asn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  uint32
+		expectErr bool
+	}{
+		{input: "12345", expected: 12345},
+		{input: "AS12345", expected: 12345},
+		{input: "as12345", expected: 12345},
+		{input: "As12345", expected: 12345},
+		{input: "012345", expected: 12345},
+		{input: "4294967295", expected: 4294967295},
+		{input: "AS0", expected: 0},
+		{input: "4294967296", expectErr: true},
+		{input: "", expectErr: true},
+		{input: "AS", expectErr: true},
+		{input: "ASN12345", expectErr: true},
+		{input: "-1", expectErr: true},
+		{input: "+1", expectErr: true},
+		{input: " 12345", expectErr: true},
+		{input: "1.10", expectErr: true},
+		{input: "12_345", expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			n, err := Parse(tc.input)
+			if tc.expectErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Parse(%q) expected ErrInvalid, got %v", tc.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tc.input, err)
+			}
+			if n != tc.expected {
+				t.Errorf("Parse(%q) = %d, expected %d", tc.input, n, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, input := range []string{"AS13335", "as13335", "13335", "0013335"} {
+		normalized, err := Normalize(input)
+		if err != nil {
+			t.Fatalf("Normalize(%q) unexpected error: %v", input, err)
+		}
+		if normalized != "13335" {
+			t.Errorf("Normalize(%q) = %q, expected 13335", input, normalized)
+		}
+	}
+}
+
+func TestReserved(t *testing.T) {
+	tests := []struct {
+		asn      uint32
+		reserved bool
+	}{
+		{asn: 0, reserved: true},
+		{asn: 1, reserved: false},
+		{asn: 13335, reserved: false},
+		{asn: 23456, reserved: true},
+		{asn: 64495, reserved: false},
+		{asn: 64496, reserved: true},
+		{asn: 64512, reserved: true},
+		{asn: 65534, reserved: true},
+		{asn: 65535, reserved: true},
+		{asn: 65551, reserved: true},
+		{asn: 131071, reserved: true},
+		{asn: 131072, reserved: false},
+		{asn: 4199999999, reserved: false},
+		{asn: 4200000000, reserved: true},
+		{asn: 4294967295, reserved: true},
+	}
+
+	for _, tc := range tests {
+		if got := Reserved(tc.asn) != ""; got != tc.reserved {
+			t.Errorf("Reserved(%d) reserved = %v, expected %v", tc.asn, got, tc.reserved)
+		}
+	}
+}
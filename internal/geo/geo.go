@@ -0,0 +1,126 @@
+// Package geo provides the continent, country and subdivision codes accepted by the MultiCDN API.
+//
+// The embedded dataset lists the continent codes used by the API, the ISO 3166-1 alpha-2
+// country codes together with the continent(s) they belong to, and the ISO 3166-2 subdivision
+// codes. Country and subdivision codes and names are taken from the Debian iso-codes package,
+// which is licensed under the LGPL 2.1 or later; see data/NOTICE and data/LICENSE.iso-codes.
+// Transcontinental countries, such as RU or TR, list every continent they may be placed under.
+package geo
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed data/*.csv
+var dataFS embed.FS
+
+// Country describes an ISO 3166-1 country
+type Country struct {
+	Code       string   // ISO 3166-1 alpha-2 code
+	Name       string   // English short name
+	Continents []string // Continent codes, the primary continent first
+}
+
+// dataset holds the parsed embedded data
+type dataset struct {
+	continents   map[string]string // continent code -> name
+	countries    map[string]Country
+	subdivisions map[string]string // full ISO 3166-2 code (e.g. US-CA) -> name
+}
+
+var loadDataset = sync.OnceValue(func() *dataset {
+	data := &dataset{
+		continents:   make(map[string]string),
+		countries:    make(map[string]Country),
+		subdivisions: make(map[string]string),
+	}
+
+	for _, record := range readCSV("data/continents.csv") {
+		data.continents[record[0]] = record[1]
+	}
+	for _, record := range readCSV("data/countries.csv") {
+		data.countries[record[0]] = Country{
+			Code:       record[0],
+			Continents: strings.Fields(record[1]),
+			Name:       record[2],
+		}
+	}
+	for _, record := range readCSV("data/subdivisions.csv") {
+		data.subdivisions[record[0]] = record[1]
+	}
+
+	return data
+})
+
+// readCSV returns the records of an embedded CSV file without its header.
+// The files are part of the binary, so a malformed file is a programming error.
+func readCSV(name string) [][]string {
+	file, err := dataFS.Open(name)
+	if err != nil {
+		panic(fmt.Sprintf("geo: opening embedded %s: %v", name, err))
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("geo: parsing embedded %s: %v", name, err))
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	return records[1:]
+}
+
+// Continents returns all continent codes in alphabetical order
+func Continents() []string {
+	codes := make([]string, 0, len(loadDataset().continents))
+	for code := range loadDataset().continents {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// IsContinent reports whether code is a known continent code
+func IsContinent(code string) bool {
+	_, ok := loadDataset().continents[code]
+	return ok
+}
+
+// ContinentName returns the English name of a continent and whether the code is known
+func ContinentName(code string) (string, bool) {
+	name, ok := loadDataset().continents[code]
+	return name, ok
+}
+
+// LookupCountry returns the country with the given ISO 3166-1 alpha-2 code and whether it is known
+func LookupCountry(code string) (Country, bool) {
+	country, ok := loadDataset().countries[code]
+	return country, ok
+}
+
+// IsCountry reports whether code is a known ISO 3166-1 alpha-2 country code
+func IsCountry(code string) bool {
+	_, ok := loadDataset().countries[code]
+	return ok
+}
+
+// CountryInContinent reports whether the country may be placed under the continent
+func CountryInContinent(country, continent string) bool {
+	c, ok := loadDataset().countries[country]
+	return ok && slices.Contains(c.Continents, continent)
+}
+
+// IsSubdivision reports whether code is a known ISO 3166-2 subdivision of the country.
+// The subdivision may be given with or without the country prefix, e.g. "CA" or "US-CA" for California.
+func IsSubdivision(country, code string) bool {
+	code = strings.TrimPrefix(code, country+"-")
+	_, ok := loadDataset().subdivisions[country+"-"+code]
+	return ok
+}
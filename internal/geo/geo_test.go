@@ -0,0 +1,119 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestContinents(t *testing.T) {
+	expected := []string{"AF", "AN", "AS", "EU", "NA", "OC", "SA"}
+	if got := Continents(); !slices.Equal(got, expected) {
+		t.Errorf("Continents() = %v, expected %v", got, expected)
+	}
+
+	if name, ok := ContinentName("EU"); !ok || name != "Europe" {
+		t.Errorf("ContinentName(EU) = %q, %v, expected Europe, true", name, ok)
+	}
+}
+
+func TestCountries(t *testing.T) {
+	tests := []struct {
+		code       string
+		known      bool
+		continents []string
+	}{
+		{code: "US", known: true, continents: []string{"NA"}},
+		{code: "GB", known: true, continents: []string{"EU"}},
+		{code: "BR", known: true, continents: []string{"SA"}},
+		{code: "RU", known: true, continents: []string{"EU", "AS"}},
+		{code: "AQ", known: true, continents: []string{"AN"}},
+		{code: "UK", known: false},
+		{code: "us", known: false},
+		{code: "", known: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			country, ok := LookupCountry(tc.code)
+			if ok != tc.known {
+				t.Fatalf("LookupCountry(%q) known = %v, expected %v", tc.code, ok, tc.known)
+			}
+			if ok && !slices.Equal(country.Continents, tc.continents) {
+				t.Errorf("LookupCountry(%q) continents = %v, expected %v", tc.code, country.Continents, tc.continents)
+			}
+		})
+	}
+}
+
+func TestEveryCountryHasKnownContinent(t *testing.T) {
+	data := loadDataset()
+	if len(data.countries) != 249 {
+		t.Errorf("Expected 249 countries, got %d", len(data.countries))
+	}
+	for code, country := range data.countries {
+		if len(country.Continents) == 0 {
+			t.Errorf("Country %s has no continent", code)
+		}
+		for _, continent := range country.Continents {
+			if !IsContinent(continent) {
+				t.Errorf("Country %s lists unknown continent %q", code, continent)
+			}
+		}
+	}
+}
+
+func TestIsSubdivision(t *testing.T) {
+	tests := []struct {
+		country  string
+		code     string
+		expected bool
+	}{
+		{country: "US", code: "CA", expected: true},
+		{country: "US", code: "US-NY", expected: true},
+		{country: "CA", code: "ON", expected: true},
+		{country: "GB", code: "ENG", expected: true},
+		{country: "US", code: "ON", expected: false},
+		{country: "CA", code: "US-CA", expected: false},
+		{country: "US", code: "", expected: false},
+	}
+
+	for _, tc := range tests {
+		if got := IsSubdivision(tc.country, tc.code); got != tc.expected {
+			t.Errorf("IsSubdivision(%q, %q) = %v, expected %v", tc.country, tc.code, got, tc.expected)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "known_continent", err: ValidateContinent("NA")},
+		{name: "unknown_continent", err: ValidateContinent("XX"), expected: `"XX" is not a continent code, expected one of AF, AN, AS, EU, NA, OC, SA`},
+		{name: "country_in_continent", err: ValidateCountry("EU", "DE")},
+		{name: "transcontinental_country", err: ValidateCountry("AS", "TR")},
+		{name: "country_without_continent", err: ValidateCountry("", "JP")},
+		{name: "country_in_unknown_continent", err: ValidateCountry("XX", "JP")},
+		{name: "unknown_country", err: ValidateCountry("EU", "UK"), expected: `"UK" is not an ISO 3166-1 alpha-2 country code`},
+		{name: "country_in_wrong_continent", err: ValidateCountry("NA", "DE"), expected: "DE (Germany) belongs to EU, not NA"},
+		{name: "known_subdivision", err: ValidateSubdivision("US", "TX")},
+		{name: "subdivision_of_unknown_country", err: ValidateSubdivision("UK", "ENG")},
+		{name: "unknown_subdivision", err: ValidateSubdivision("US", "XX"), expected: `"XX" is not an ISO 3166-2 subdivision code of US`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expected == "" {
+				if tc.err != nil {
+					t.Errorf("Expected no error, got %v", tc.err)
+				}
+				return
+			}
+			if tc.err == nil || tc.err.Error() != tc.expected {
+				t.Errorf("Expected error %q, got %v", tc.expected, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package geo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateContinent returns an error describing why code is not a known continent code
+func ValidateContinent(code string) error {
+	if IsContinent(code) {
+		return nil
+	}
+	return fmt.Errorf("%q is not a continent code, expected one of %s", code, strings.Join(Continents(), ", "))
+}
+
+// ValidateCountry returns an error describing why code is not a known country of the continent.
+// An empty continent only checks the country code itself.
+func ValidateCountry(continent, code string) error {
+	country, ok := LookupCountry(code)
+	if !ok {
+		return fmt.Errorf("%q is not an ISO 3166-1 alpha-2 country code", code)
+	}
+	if continent == "" || !IsContinent(continent) || CountryInContinent(code, continent) {
+		return nil
+	}
+	return fmt.Errorf("%s (%s) belongs to %s, not %s", code, country.Name, strings.Join(country.Continents, " or "), continent)
+}
+
+// ValidateSubdivision returns an error describing why code is not a known ISO 3166-2 subdivision of the country
+func ValidateSubdivision(country, code string) error {
+	if !IsCountry(country) || IsSubdivision(country, code) {
+		return nil
+	}
+	return fmt.Errorf("%q is not an ISO 3166-2 subdivision code of %s", code, country)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// adoptVersion returns the version an update adopting an existing configuration must still find on the API,
+// so that changes made between reading and overwriting the configuration are not lost.
+// It is empty when optimistic concurrency is disabled.
+func (c *APIClient) adoptVersion(existingVersion string) string {
+	if !c.optimisticConcurrency {
+		return ""
+	}
+	return existingVersion
+}
+
+// addAlreadyExistsError reports a create rejected because a configuration with the same ID already exists
+func addAlreadyExistsError(diags *diag.Diagnostics, kind string, resourceID int64) {
+	diags.AddError(
+		"Configuration Already Exists",
+		fmt.Sprintf("A %s with ID %d already exists. Import it with terraform import to manage it with Terraform, "+
+			"or set adopt_existing = true in the provider configuration to adopt and overwrite existing configurations on create.", kind, resourceID),
+	)
+}
+
+// addAdoptedWarning reports an existing configuration that was overwritten and recorded in state instead of being created
+func addAdoptedWarning(diags *diag.Diagnostics, kind string, resourceID int64, previousVersion string) {
+	previous := "its previous content"
+	if previousVersion != "" {
+		previous = fmt.Sprintf("its previous version %q", previousVersion)
+	}
+
+	diags.AddWarning(
+		"Adopted Existing Configuration",
+		fmt.Sprintf("A %s with ID %d already existed, so it was adopted instead of created: %s "+
+			"was overwritten with the planned configuration and it is now managed by Terraform, "+
+			"which deletes it when the resource is destroyed.", kind, resourceID, previous),
+	)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/cdnclient"
+)
+
+// Ensure data source implements required interfaces
+var (
+	_ datasource.DataSource              = &cdnConfigsDataSource{}
+	_ datasource.DataSourceWithConfigure = &cdnConfigsDataSource{}
+)
+
+// cdnConfigsDataSource is the data source implementation
+type cdnConfigsDataSource struct {
+	client *APIClient
+}
+
+// cdnConfigsDataSourceModel maps the data source schema
+type cdnConfigsDataSourceModel struct {
+	ContentType         types.String         `tfsdk:"content_type"`
+	DescriptionContains types.String         `tfsdk:"description_contains"`
+	CdnName             types.String         `tfsdk:"cdn_name"`
+	ClientCdnID         types.String         `tfsdk:"client_cdn_id"`
+	Configs             []cdnDataSourceModel `tfsdk:"configs"`
+}
+
+// NewCdnConfigsDataSource creates a new data source listing CDN configurations
+func NewCdnConfigsDataSource() datasource.DataSource {
+	return &cdnConfigsDataSource{}
+}
+
+// Metadata returns the data source metadata
+func (d *cdnConfigsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "multicdn_cdn_configs"
+}
+
+// Schema defines the schema for the data source
+func (d *cdnConfigsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all CDN configuration documents of the account, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			"content_type": schema.StringAttribute{
+				Description: "Only return configurations with exactly this content type",
+				Optional:    true,
+			},
+			"description_contains": schema.StringAttribute{
+				Description: "Only return configurations whose description contains this substring",
+				Optional:    true,
+			},
+			"cdn_name": schema.StringAttribute{
+				Description: "Only return configurations with a CDN entry of this name",
+				Optional:    true,
+			},
+			"client_cdn_id": schema.StringAttribute{
+				Description: "Only return configurations with a CDN entry of this client CDN identifier",
+				Optional:    true,
+			},
+			"configs": schema.ListNestedAttribute{
+				Description: "CDN configurations matching all given filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cdnConfigDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the data source with the provider client
+func (d *cdnConfigsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *APIClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read lists the CDN configurations from the API
+func (d *cdnConfigsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Read the configuration
+	var state cdnConfigsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Walk all pages of CDN configurations
+	apiConfigs, err := d.client.cdn.GetAllCdnConfigs(ctx, cdnclient.DefaultPageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing CDN Configurations",
+			fmt.Sprintf("Unable to list CDN configurations: %s", err),
+		)
+		return
+	}
+
+	// Keep only the configurations matching every filter
+	state.Configs = make([]cdnDataSourceModel, 0, len(apiConfigs))
+	for i := range apiConfigs {
+		if state.matches(&apiConfigs[i]) {
+			state.Configs = append(state.Configs, cdnDataSourceModelFromAPI(&apiConfigs[i]))
+		}
+	}
+
+	// Save the data into Terraform state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// matches reports whether the API configuration satisfies all filters set on the model
+func (m *cdnConfigsDataSourceModel) matches(config *cdnclient.CdnConfigurationResponse) bool {
+	if !m.ContentType.IsNull() {
+		if config.ContentType == nil || *config.ContentType != m.ContentType.ValueString() {
+			return false
+		}
+	}
+
+	if !m.DescriptionContains.IsNull() {
+		if config.Description == nil || !strings.Contains(*config.Description, m.DescriptionContains.ValueString()) {
+			return false
+		}
+	}
+
+	if !m.CdnName.IsNull() && !hasCdnEntry(config.Cdns, func(entry cdnclient.CdnEntry) bool {
+		return entry.CdnName == m.CdnName.ValueString()
+	}) {
+		return false
+	}
+
+	if !m.ClientCdnID.IsNull() && !hasCdnEntry(config.Cdns, func(entry cdnclient.CdnEntry) bool {
+		return entry.ClientCdnID == m.ClientCdnID.ValueString()
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// hasCdnEntry reports whether any CDN entry satisfies the predicate
+func hasCdnEntry(entries []cdnclient.CdnEntry, predicate func(cdnclient.CdnEntry) bool) bool {
+	for _, entry := range entries {
+		if predicate(entry) {
+			return true
+		}
+	}
+	return false
+}
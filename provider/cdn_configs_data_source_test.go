@@ -0,0 +1,48 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance test listing CDN configurations through the data source with filters
+func TestAccCdnConfigsDataSource_filters(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCdnResourceConfig(mockServer.URL, "Owned by another team") + `
+data "multicdn_cdn_configs" "all" {
+  depends_on = [multicdn_cdn_config.test]
+}
+
+data "multicdn_cdn_configs" "matching" {
+  content_type         = "application/json"
+  description_contains = "another team"
+  client_cdn_id        = "cdn2"
+
+  depends_on = [multicdn_cdn_config.test]
+}
+
+data "multicdn_cdn_configs" "other_cdn" {
+  cdn_name = "cdn3"
+
+  depends_on = [multicdn_cdn_config.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.multicdn_cdn_configs.all", "configs.#", "1"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_configs.all", "configs.0.resource_id", "12345"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_configs.matching", "configs.#", "1"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_configs.matching", "configs.0.cdns.#", "2"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_configs.matching", "configs.0.cdns.1.client_cdn_id", "cdn2"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_configs.other_cdn", "configs.#", "0"),
+				),
+			},
+		},
+	})
+}
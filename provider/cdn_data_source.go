@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/cdnclient"
+)
+
+// Ensure data source implements required interfaces
+var (
+	_ datasource.DataSource              = &cdnDataSource{}
+	_ datasource.DataSourceWithConfigure = &cdnDataSource{}
+)
+
+// cdnDataSource is the data source implementation
+type cdnDataSource struct {
+	client *APIClient
+}
+
+// cdnDataSourceModel maps the data source schema to the API client model
+type cdnDataSourceModel struct {
+	ResourceID          types.Int64               `tfsdk:"resource_id"`
+	ContentType         types.String              `tfsdk:"content_type"`
+	Description         types.String              `tfsdk:"description"`
+	Version             types.String              `tfsdk:"version"`
+	LastUpdated         types.String              `tfsdk:"last_updated"`
+	Cdns                []cdnEntryModel           `tfsdk:"cdns"`
+	CdnEnablementMap    *cdnEnablementMapModel    `tfsdk:"cdn_enablement_map"`
+	TrafficDistribution *trafficDistributionModel `tfsdk:"traffic_distribution"`
+}
+
+// NewCdnDataSource creates a new CDN configuration data source
+func NewCdnDataSource() datasource.DataSource {
+	return &cdnDataSource{}
+}
+
+// Metadata returns the data source metadata
+func (d *cdnDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "multicdn_cdn_config"
+}
+
+// Schema defines the schema for the data source
+func (d *cdnDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := cdnConfigDataSourceAttributes()
+	attributes["resource_id"] = schema.Int64Attribute{
+		Description: "Unique ID of the CDN configuration to read",
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Reads an existing CDN configuration document",
+		Attributes:  attributes,
+	}
+}
+
+// Configure configures the data source with the provider client
+func (d *cdnDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *APIClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read reads the CDN configuration from the API
+func (d *cdnDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Read the configuration
+	var config cdnDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the resource ID from configuration
+	resourceID := config.ResourceID.ValueInt64()
+
+	// Call the API client to get the CDN configuration
+	apiConfig, err := d.client.cdn.GetCdnConfig(ctx, resourceID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading CDN Configuration",
+			fmt.Sprintf("Unable to read CDN configuration ID %d: %s", resourceID, err),
+		)
+		return
+	}
+
+	// Convert API model to Terraform model
+	state := cdnDataSourceModelFromAPI(apiConfig)
+
+	// Save the data into Terraform state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// cdnDataSourceModelFromAPI converts an API CDN configuration into the data source model,
+// reusing the resource conversion so both expose the same nested shape
+func cdnDataSourceModelFromAPI(apiConfig *cdnclient.CdnConfigurationResponse) cdnDataSourceModel {
+	var converter cdnResource
+	var model cdnResourceModel
+	converter.convertFromAPIModel(apiConfig, &model)
+
+	return cdnDataSourceModel{
+		ResourceID:          model.ResourceID,
+		ContentType:         model.ContentType,
+		Description:         model.Description,
+		Version:             model.Version,
+		LastUpdated:         model.LastUpdated,
+		Cdns:                model.Cdns,
+		CdnEnablementMap:    model.CdnEnablementMap,
+		TrafficDistribution: model.TrafficDistribution,
+	}
+}
+
+// cdnConfigDataSourceAttributes returns the computed attributes describing a CDN configuration document.
+// The nested shape mirrors the multicdn_cdn_config resource schema.
+func cdnConfigDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"resource_id": schema.Int64Attribute{
+			Description: "Unique ID of the CDN configuration",
+			Computed:    true,
+		},
+		"content_type": schema.StringAttribute{
+			Description: "Content type of the CDN configuration",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description of the CDN configuration",
+			Computed:    true,
+		},
+		"version": schema.StringAttribute{
+			Description: "Version of the CDN configuration",
+			Computed:    true,
+		},
+		"last_updated": schema.StringAttribute{
+			Description: "Timestamp of when the configuration was last updated",
+			Computed:    true,
+		},
+		"cdns": schema.ListNestedAttribute{
+			Description: "List of CDN provider entries",
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"cdn_name": schema.StringAttribute{
+						Description: "Name of the CDN provider",
+						Computed:    true,
+					},
+					"description": schema.StringAttribute{
+						Description: "Description of the CDN provider entry",
+						Computed:    true,
+					},
+					"fqdn": schema.StringAttribute{
+						Description: "Fully qualified domain name for the CDN",
+						Computed:    true,
+					},
+					"client_cdn_id": schema.StringAttribute{
+						Description: "Client CDN identifier",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"cdn_enablement_map": schema.SingleNestedAttribute{
+			Description: "CDN enablement configuration",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"world_default": schema.ListAttribute{
+					Description: "Default CDNs enabled globally",
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+				"asn_overrides": cdnDataSourceASNOverridesAttribute("ASN-specific CDN overrides"),
+				"continents": schema.MapNestedAttribute{
+					Description: "Continent-specific enablement configurations",
+					Computed:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"default": schema.ListAttribute{
+								Description: "Default CDNs enabled for the continent",
+								Computed:    true,
+								ElementType: types.StringType,
+							},
+							"countries": schema.MapNestedAttribute{
+								Description: "Country-specific enablement configurations",
+								Computed:    true,
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"default": schema.ListAttribute{
+											Description: "Default CDNs enabled for the country",
+											Computed:    true,
+											ElementType: types.StringType,
+										},
+										"asn_overrides": cdnDataSourceASNOverridesAttribute("ASN-specific CDN overrides for the country"),
+										"subdivisions": schema.MapNestedAttribute{
+											Description: "Subdivision-specific enablement configurations",
+											Computed:    true,
+											NestedObject: schema.NestedAttributeObject{
+												Attributes: map[string]schema.Attribute{
+													"asn_overrides": cdnDataSourceASNOverridesAttribute("ASN-specific CDN overrides for the subdivision"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"traffic_distribution": schema.SingleNestedAttribute{
+			Description: "Traffic distribution configuration",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"world_default": cdnDataSourceTrafficOptionListAttribute("Global default traffic distribution"),
+				"continents": schema.MapNestedAttribute{
+					Description: "Continent-specific traffic distributions",
+					Computed:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"default": cdnDataSourceTrafficOptionListAttribute("Default traffic distribution for the continent"),
+							"countries": schema.MapNestedAttribute{
+								Description: "Country-specific traffic distributions",
+								Computed:    true,
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"default": cdnDataSourceTrafficOptionListAttribute("Default traffic distribution for the country"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cdnDataSourceASNOverridesAttribute returns a computed map of ASN to enabled CDN identifiers
+func cdnDataSourceASNOverridesAttribute(description string) schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: description,
+		Computed:    true,
+		ElementType: types.ListType{
+			ElemType: types.StringType,
+		},
+	}
+}
+
+// cdnDataSourceTrafficOptionListAttribute returns a computed list of traffic distribution options
+func cdnDataSourceTrafficOptionListAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Computed:    true,
+		Attributes: map[string]schema.Attribute{
+			"options": schema.ListNestedAttribute{
+				Description: "Traffic distribution options",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the traffic option",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the traffic option",
+							Computed:    true,
+						},
+						"equal_weight": schema.BoolAttribute{
+							Description: "Whether traffic is distributed equally",
+							Computed:    true,
+						},
+						"distribution": schema.ListNestedAttribute{
+							Description: "Distribution entries",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Description: "CDN identifier",
+										Computed:    true,
+									},
+									"weight": schema.Int64Attribute{
+										Description: "Traffic weight",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance test reading a CDN configuration through the data source
+func TestAccCdnConfigDataSource_basic(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCdnResourceConfig(mockServer.URL, "Owned by another team") + `
+data "multicdn_cdn_config" "test" {
+  resource_id = multicdn_cdn_config.test.resource_id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.multicdn_cdn_config.test", "resource_id", "12345"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_config.test", "description", "Owned by another team"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_config.test", "cdns.#", "2"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_config.test", "cdns.0.client_cdn_id", "cdn1"),
+					resource.TestCheckResourceAttrPair("data.multicdn_cdn_config.test", "cdn_enablement_map.world_default.#", "multicdn_cdn_config.test", "cdn_enablement_map.world_default.#"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_config.test", "cdn_enablement_map.continents.EU.countries.DE.default.#", "2"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_config.test", "traffic_distribution.world_default.options.0.distribution.0.weight", "70"),
+					resource.TestCheckResourceAttr("data.multicdn_cdn_config.test", "traffic_distribution.continents.EU.default.options.0.equal_weight", "true"),
+				),
+			},
+		},
+	})
+}
+
+// Acceptance test for reading a CDN configuration that does not exist
+func TestAccCdnConfigDataSource_notFound(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "multicdn" {
+  api_key = "test-key"
+  api_secret = "test-secret"
+  base_url = "%s"
+}
+
+data "multicdn_cdn_config" "missing" {
+  resource_id = 404
+}
+`, mockServer.URL),
+				ExpectError: regexp.MustCompile(`Unable to read CDN configuration ID 404`),
+			},
+		},
+	})
+}
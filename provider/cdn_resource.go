@@ -7,20 +7,28 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/constellix/terraform-provider-constellix-multicdn/clients/cdnclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
+	"github.com/constellix/terraform-provider-constellix-multicdn/internal/asn"
 )
 
 // Ensure resource implements required interfaces
 var (
-	_ resource.Resource                = &cdnResource{}
-	_ resource.ResourceWithImportState = &cdnResource{}
+	_ resource.Resource                   = &cdnResource{}
+	_ resource.ResourceWithImportState    = &cdnResource{}
+	_ resource.ResourceWithValidateConfig = &cdnResource{}
+	_ resource.ResourceWithModifyPlan     = &cdnResource{}
 )
 
 // cdnResource is the resource implementation
@@ -35,6 +43,8 @@ type cdnResourceModel struct {
 	Description         types.String              `tfsdk:"description"`
 	Version             types.String              `tfsdk:"version"`
 	LastUpdated         types.String              `tfsdk:"last_updated"`
+	Timeouts            timeouts.Value            `tfsdk:"timeouts"`
+	DeletionProtection  types.Bool                `tfsdk:"deletion_protection"`
 	Cdns                []cdnEntryModel           `tfsdk:"cdns"`
 	CdnEnablementMap    *cdnEnablementMapModel    `tfsdk:"cdn_enablement_map"`
 	TrafficDistribution *trafficDistributionModel `tfsdk:"traffic_distribution"`
@@ -125,7 +135,7 @@ func (r *cdnResource) Metadata(_ context.Context, _ resource.MetadataRequest, re
 }
 
 // Schema defines the schema for the resource
-func (r *cdnResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *cdnResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a CDN configuration document",
 		Attributes: map[string]schema.Attribute{
@@ -145,12 +155,25 @@ func (r *cdnResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Optional:    true,
 			},
 			"version": schema.StringAttribute{
-				Description: "Version of the CDN configuration",
+				Description: "Version of the CDN configuration. Set by the API unless configured. A configured version is kept on every update, so optimistic concurrency cannot detect changes made outside Terraform",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
-				Description: "Timestamp of when the configuration was last updated",
+				Description: "Timestamp of when the configuration was last updated, set by the API",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeoutsAttribute(ctx),
+			"deletion_protection": schema.BoolAttribute{
+				Description: deletionProtectionAttributeDescription("CDN configuration"),
 				Optional:    true,
+				Computed:    true,
 			},
 			"cdns": schema.ListNestedAttribute{
 				Description: "List of CDN provider entries",
@@ -422,12 +445,31 @@ func (r *cdnResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Convert Terraform model to API model
 	apiConfig := r.convertToAPIModel(&plan)
 
 	// Call the API client to create the CDN configuration
 	createdConfig, err := r.client.cdn.CreateCdnConfig(ctx, apiConfig)
-	if err != nil {
+	if response.IsConflict(err) {
+		if !r.client.adoptExisting {
+			addAlreadyExistsError(&resp.Diagnostics, "CDN configuration", apiConfig.ResourceID)
+			return
+		}
+
+		createdConfig = r.adoptExisting(ctx, apiConfig, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating CDN Configuration",
 			fmt.Sprintf("Unable to create CDN configuration, got error: %s", err),
@@ -447,12 +489,45 @@ func (r *cdnResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	// Convert API model back to Terraform model
 	r.convertFromAPIModel(createdConfig, &plan)
+	plan.DeletionProtection = r.client.deletionProtectionOrDefault(plan.DeletionProtection)
 
 	// Save the data into Terraform state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// adoptExisting overwrites a CDN configuration that already exists with the planned one and returns the result
+func (r *cdnResource) adoptExisting(ctx context.Context, apiConfig *cdnclient.CdnConfiguration, diags *diag.Diagnostics) *cdnclient.CdnConfigurationResponse {
+	resourceID := apiConfig.ResourceID
+
+	existing, err := r.client.cdn.GetCdnConfig(ctx, resourceID)
+	if err != nil {
+		diags.AddError(
+			"Error Adopting CDN Configuration",
+			fmt.Sprintf("Unable to read existing CDN configuration ID %d: %s", resourceID, err),
+		)
+		return nil
+	}
+
+	var existingVersion string
+	if existing.Version != nil {
+		existingVersion = *existing.Version
+	}
+
+	expectedVersion := r.client.adoptVersion(existingVersion)
+	updatedConfig, err := r.client.cdn.UpdateCdnConfig(ctx, resourceID, apiConfig, updateOptions(expectedVersion)...)
+	if err != nil {
+		diags.AddError(
+			"Error Adopting CDN Configuration",
+			fmt.Sprintf("Unable to overwrite existing CDN configuration ID %d: %s", resourceID, err),
+		)
+		return nil
+	}
+
+	addAdoptedWarning(diags, "CDN configuration", resourceID, existingVersion)
+	return updatedConfig
+}
+
 // Read reads the CDN configuration from the API
 func (r *cdnResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Read the current state
@@ -463,11 +538,28 @@ func (r *cdnResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get the resource ID from state
 	resourceID := state.ResourceID.ValueInt64()
 
 	// Call the API client to get the CDN configuration
 	config, err := r.client.cdn.GetCdnConfig(ctx, resourceID)
+	if response.IsNotFound(err) {
+		// The configuration was deleted outside of Terraform, so plan to re-create it
+		tflog.Warn(ctx, "CDN configuration not found, removing from state", map[string]interface{}{
+			"resource_id": resourceID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading CDN Configuration",
@@ -478,6 +570,7 @@ func (r *cdnResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	// Convert API model to Terraform model
 	r.convertFromAPIModel(config, &state)
+	state.DeletionProtection = r.client.deletionProtectionOrDefault(state.DeletionProtection)
 
 	// Save the updated data into Terraform state
 	diags = resp.State.Set(ctx, state)
@@ -494,14 +587,33 @@ func (r *cdnResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Get the resource ID from plan
 	resourceID := plan.ResourceID.ValueInt64()
 
+	// Only overwrite the version the plan was based on
+	expectedVersion := r.client.expectedVersion(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert Terraform model to API model
 	apiConfig := r.convertToAPIModel(&plan)
 
 	// Call the API client to update the CDN configuration
-	updatedConfig, err := r.client.cdn.UpdateCdnConfig(ctx, resourceID, apiConfig)
+	updatedConfig, err := r.client.cdn.UpdateCdnConfig(ctx, resourceID, apiConfig, updateOptions(expectedVersion)...)
+	if isConcurrentModification(err, expectedVersion) {
+		addConcurrentModificationError(&resp.Diagnostics, "CDN configuration", resourceID, expectedVersion)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating CDN Configuration",
@@ -512,6 +624,7 @@ func (r *cdnResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	// Convert API model back to Terraform model
 	r.convertFromAPIModel(updatedConfig, &plan)
+	plan.DeletionProtection = r.client.deletionProtectionOrDefault(plan.DeletionProtection)
 
 	// Save the updated data into Terraform state
 	diags = resp.State.Set(ctx, plan)
@@ -528,11 +641,33 @@ func (r *cdnResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Get the resource ID from state
 	resourceID := state.ResourceID.ValueInt64()
 
+	// Protected resources must have deletion_protection set to false in a prior apply
+	if r.client.deletionProtectionOrDefault(state.DeletionProtection).ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "CDN configuration", resourceID)
+		return
+	}
+
 	// Call the API client to delete the CDN configuration
 	err := r.client.cdn.DeleteCdnConfig(ctx, resourceID)
+	if response.IsNotFound(err) {
+		// Already gone, nothing left to delete
+		tflog.Warn(ctx, "CDN configuration already deleted", map[string]interface{}{
+			"resource_id": resourceID,
+		})
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting CDN Configuration",
@@ -575,18 +710,12 @@ func (r *cdnResource) convertToAPIModel(tfModel *cdnResourceModel) *cdnclient.Cd
 		apiModel.Description = &description
 	}
 
-	if !tfModel.Version.IsNull() && tfModel.Version.ValueString() != "" {
+	// last_updated is set by the API and never sent
+	if !tfModel.Version.IsNull() && !tfModel.Version.IsUnknown() && tfModel.Version.ValueString() != "" {
 		version := tfModel.Version.ValueString()
 		apiModel.Version = &version
 	}
 
-	if !tfModel.LastUpdated.IsNull() && tfModel.LastUpdated.ValueString() != "" {
-		parsedTime, err := time.Parse(time.RFC3339, tfModel.LastUpdated.ValueString())
-		if err == nil {
-			apiModel.LastUpdated = &parsedTime
-		}
-	}
-
 	// Convert CDN entries
 	if len(tfModel.Cdns) > 0 {
 		apiModel.Cdns = make([]cdnclient.CdnEntry, 0, len(tfModel.Cdns))
@@ -619,12 +748,12 @@ func (r *cdnResource) convertToAPIModel(tfModel *cdnResourceModel) *cdnclient.Cd
 		// ASN overrides
 		if tfModel.CdnEnablementMap.ASNOverrides != nil && len(tfModel.CdnEnablementMap.ASNOverrides) > 0 {
 			apiModel.CdnEnablementMap.ASNOverrides = make(map[string][]string)
-			for asn, cdnList := range tfModel.CdnEnablementMap.ASNOverrides {
+			for key, cdnList := range tfModel.CdnEnablementMap.ASNOverrides {
 				apiCdns := make([]string, 0, len(cdnList))
 				for _, cdn := range cdnList {
 					apiCdns = append(apiCdns, cdn.ValueString())
 				}
-				apiModel.CdnEnablementMap.ASNOverrides[asn] = apiCdns
+				apiModel.CdnEnablementMap.ASNOverrides[normalizeASNKey(key)] = apiCdns
 			}
 		}
 
@@ -661,12 +790,12 @@ func (r *cdnResource) convertToAPIModel(tfModel *cdnResourceModel) *cdnclient.Cd
 						// Country ASN overrides
 						if tfCountry.ASNOverrides != nil && len(tfCountry.ASNOverrides) > 0 {
 							apiCountry.ASNOverrides = make(map[string][]string)
-							for asn, cdnList := range tfCountry.ASNOverrides {
+							for key, cdnList := range tfCountry.ASNOverrides {
 								apiCdns := make([]string, 0, len(cdnList))
 								for _, cdn := range cdnList {
 									apiCdns = append(apiCdns, cdn.ValueString())
 								}
-								apiCountry.ASNOverrides[asn] = apiCdns
+								apiCountry.ASNOverrides[normalizeASNKey(key)] = apiCdns
 							}
 						}
 
@@ -680,12 +809,12 @@ func (r *cdnResource) convertToAPIModel(tfModel *cdnResourceModel) *cdnclient.Cd
 								// Subdivision ASN overrides
 								if tfSubdivision.ASNOverrides != nil && len(tfSubdivision.ASNOverrides) > 0 {
 									apiSubdivision.ASNOverrides = make(map[string][]string)
-									for asn, cdnList := range tfSubdivision.ASNOverrides {
+									for key, cdnList := range tfSubdivision.ASNOverrides {
 										apiCdns := make([]string, 0, len(cdnList))
 										for _, cdn := range cdnList {
 											apiCdns = append(apiCdns, cdn.ValueString())
 										}
-										apiSubdivision.ASNOverrides[asn] = apiCdns
+										apiSubdivision.ASNOverrides[normalizeASNKey(key)] = apiCdns
 									}
 								}
 
@@ -898,7 +1027,8 @@ func (r *cdnResource) convertFromAPIModel(apiModel *cdnclient.CdnConfigurationRe
 		tfModel.Cdns = append(tfModel.Cdns, tfEntry)
 	}
 
-	// Convert CDN enablement map
+	// Convert CDN enablement map, keeping the configured spelling of ASN keys
+	prior := tfModel.CdnEnablementMap
 	tfModel.CdnEnablementMap = &cdnEnablementMapModel{
 		WorldDefault: make([]types.String, 0), // Initialize as empty slice
 		ASNOverrides: nil,                     // Initialize as nil, not empty map
@@ -918,12 +1048,13 @@ func (r *cdnResource) convertFromAPIModel(apiModel *cdnclient.CdnConfigurationRe
 	// ASN overrides
 	if len(apiModel.CdnEnablementMap.ASNOverrides) > 0 {
 		tfModel.CdnEnablementMap.ASNOverrides = make(map[string][]types.String)
-		for asn, cdnList := range apiModel.CdnEnablementMap.ASNOverrides {
+		priorKeys := prior.asnOverrides()
+		for key, cdnList := range apiModel.CdnEnablementMap.ASNOverrides {
 			tfCdns := make([]types.String, 0, len(cdnList))
 			for _, cdn := range cdnList {
 				tfCdns = append(tfCdns, types.StringValue(cdn))
 			}
-			tfModel.CdnEnablementMap.ASNOverrides[asn] = tfCdns
+			tfModel.CdnEnablementMap.ASNOverrides[asnKeyFromAPI(key, priorKeys)] = tfCdns
 		}
 	}
 
@@ -963,12 +1094,13 @@ func (r *cdnResource) convertFromAPIModel(apiModel *cdnclient.CdnConfigurationRe
 					// Country ASN overrides
 					if len(apiCountry.ASNOverrides) > 0 {
 						tfCountry.ASNOverrides = make(map[string][]types.String)
-						for asn, cdnList := range apiCountry.ASNOverrides {
+						priorKeys := prior.country(continent, country).asnOverrides()
+						for key, cdnList := range apiCountry.ASNOverrides {
 							tfCdns := make([]types.String, 0, len(cdnList))
 							for _, cdn := range cdnList {
 								tfCdns = append(tfCdns, types.StringValue(cdn))
 							}
-							tfCountry.ASNOverrides[asn] = tfCdns
+							tfCountry.ASNOverrides[asnKeyFromAPI(key, priorKeys)] = tfCdns
 						}
 					}
 
@@ -982,12 +1114,13 @@ func (r *cdnResource) convertFromAPIModel(apiModel *cdnclient.CdnConfigurationRe
 							// Subdivision ASN overrides
 							if len(apiSubdivision.ASNOverrides) > 0 {
 								tfSubdivision.ASNOverrides = make(map[string][]types.String)
-								for asn, cdnList := range apiSubdivision.ASNOverrides {
+								priorKeys := prior.country(continent, country).subdivision(subdivision).asnOverrides()
+								for key, cdnList := range apiSubdivision.ASNOverrides {
 									tfCdns := make([]types.String, 0, len(cdnList))
 									for _, cdn := range cdnList {
 										tfCdns = append(tfCdns, types.StringValue(cdn))
 									}
-									tfSubdivision.ASNOverrides[asn] = tfCdns
+									tfSubdivision.ASNOverrides[asnKeyFromAPI(key, priorKeys)] = tfCdns
 								}
 							}
 
@@ -1173,3 +1306,66 @@ func (r *cdnResource) convertFromAPIModel(apiModel *cdnclient.CdnConfigurationRe
 		}
 	}
 }
+
+// normalizeASNKey returns the canonical form of an asn_overrides key used by the API, the plain
+// decimal number. Invalid keys are rejected by ValidateConfig and returned unchanged.
+func normalizeASNKey(key string) string {
+	normalized, err := asn.Normalize(key)
+	if err != nil {
+		return key
+	}
+	return normalized
+}
+
+// asnKeyFromAPI returns the key of a prior asn_overrides map referring to the same ASN as the
+// API key, so state keeps the configured spelling and "AS13335" in the configuration does not
+// diff against the canonical "13335" returned by the API
+func asnKeyFromAPI(key string, prior map[string][]types.String) string {
+	normalized := normalizeASNKey(key)
+	for priorKey := range prior {
+		if normalizeASNKey(priorKey) == normalized {
+			return priorKey
+		}
+	}
+	return key
+}
+
+// asnOverrides returns the world ASN overrides, or nil if the enablement map is nil
+func (m *cdnEnablementMapModel) asnOverrides() map[string][]types.String {
+	if m == nil {
+		return nil
+	}
+	return m.ASNOverrides
+}
+
+// country returns the enablement of a country under a continent, or nil if it is not configured
+func (m *cdnEnablementMapModel) country(continentCode, countryCode string) *countryEnablementModel {
+	if m == nil || m.Continents[continentCode] == nil {
+		return nil
+	}
+	return m.Continents[continentCode].Countries[countryCode]
+}
+
+// asnOverrides returns the country ASN overrides, or nil if the country is nil
+func (m *countryEnablementModel) asnOverrides() map[string][]types.String {
+	if m == nil {
+		return nil
+	}
+	return m.ASNOverrides
+}
+
+// subdivision returns the enablement of a subdivision of the country, or nil if it is not configured
+func (m *countryEnablementModel) subdivision(code string) *subdivisionEnablementModel {
+	if m == nil {
+		return nil
+	}
+	return m.Subdivisions[code]
+}
+
+// asnOverrides returns the subdivision ASN overrides, or nil if the subdivision is nil
+func (m *subdivisionEnablementModel) asnOverrides() map[string][]types.String {
+	if m == nil {
+		return nil
+	}
+	return m.ASNOverrides
+}
@@ -1,28 +1,33 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/constellix/terraform-provider-constellix-multicdn/clients/cdnclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/multicdntest"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 // Test helper functions
-func testAccCheckCdnConfigExists(resourceID int64, configs map[int64]*cdnclient.CdnConfigurationResponse) resource.TestCheckFunc {
+func testAccCheckCdnConfigExists(resourceID int64, mockServer *multicdntest.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		if _, exists := configs[resourceID]; !exists {
+		if _, exists := mockServer.CdnConfig(resourceID); !exists {
 			return fmt.Errorf("CDN configuration with ID %d does not exist", resourceID)
 		}
 		return nil
 	}
 }
 
-func testAccCheckCdnConfigDescription(resourceID int64, expectedDesc string, configs map[int64]*cdnclient.CdnConfigurationResponse) resource.TestCheckFunc {
+func testAccCheckCdnConfigDescription(resourceID int64, expectedDesc string, mockServer *multicdntest.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		config, exists := configs[resourceID]
+		config, exists := mockServer.CdnConfig(resourceID)
 		if !exists {
 			return fmt.Errorf("CDN configuration with ID %d does not exist", resourceID)
 		}
@@ -33,12 +38,23 @@ func testAccCheckCdnConfigDescription(resourceID int64, expectedDesc string, con
 	}
 }
 
+// Helper function to verify that last_updated in state matches the timestamp set by the API
+func testAccCheckCdnConfigLastUpdated(resourceName string, resourceID int64, mockServer *multicdntest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config, exists := mockServer.CdnConfig(resourceID)
+		if !exists || config.LastUpdated == nil {
+			return fmt.Errorf("CDN configuration with ID %d does not exist or has no last updated timestamp", resourceID)
+		}
+		return resource.TestCheckResourceAttr(resourceName, "last_updated", config.LastUpdated.Format(time.RFC3339))(s)
+	}
+}
+
 // Test configuration templates
 func testAccCdnResourceConfig(serverURL, description string) string {
 	return fmt.Sprintf(`
 provider "multicdn" {
-  api_key = "api_key"
-  api_secret = "api_secret"
+  api_key = "test-key"
+  api_secret = "test-secret"
   base_url = "%s"
 }
 
@@ -52,12 +68,12 @@ resource "multicdn_cdn_config" "test" {
       cdn_name = "cdn1"
       description = "Primary CDN"
       fqdn = "cdn1.example.com"
-      client_cdn_id = "cdn1_id"
+      client_cdn_id = "cdn1"
     },
     {
       cdn_name = "cdn2"
       fqdn = "cdn2.example.com"
-      client_cdn_id = "cdn2_id"
+      client_cdn_id = "cdn2"
     }
   ]
   
@@ -65,7 +81,7 @@ resource "multicdn_cdn_config" "test" {
     world_default = ["cdn1", "cdn2"]
     asn_overrides = {
 	  "12345" = ["cdn1"]
-	  "67890" = ["cdn2"]
+	  "36890" = ["cdn2"]
 	}
     continents = {
       "EU" = {
@@ -75,7 +91,7 @@ resource "multicdn_cdn_config" "test" {
           "DE" = {
             default = ["cdn1", "cdn2"],
 			asn_overrides ={
-              "703": ["CDN-B-001", "CDN-C-001"]
+              "703": ["cdn1", "cdn2"]
             }
           }
         }
@@ -149,8 +165,8 @@ resource "multicdn_cdn_config" "test" {
 func testAccCdnResourceConfigWithInvalidID(serverURL string) string {
 	return fmt.Sprintf(`
 provider "multicdn" {
-  api_key = "api_key"
-  api_secret = "api_secret"
+  api_key = "test-key"
+  api_secret = "test-secret"
   base_url = "%s"
 }
 
@@ -163,7 +179,7 @@ resource "multicdn_cdn_config" "test" {
     {
       cdn_name = "cdn1"
       fqdn = "cdn1.example.com"
-      client_cdn_id = "cdn1_id"
+      client_cdn_id = "cdn1"
     }
   ]
   
@@ -198,9 +214,26 @@ resource "multicdn_cdn_config" "test" {
 `, serverURL)
 }
 
+// Test that the configurations of the acceptance tests pass plan-time validation, without Terraform
+func TestCdnConfigResource_fixturesValidate(t *testing.T) {
+	fixtures := map[string]string{
+		"default":               testAccCdnResourceConfig("http://localhost", "Test Description"),
+		"comprehensive":         testAccCdnResourceConfigComprehensive("http://localhost", "Test Description"),
+		"comprehensive_updated": testAccCdnResourceConfigComprehensiveUpdated("http://localhost", "Test Description"),
+		"minimal":               testAccCdnResourceConfigMinimal("http://localhost"),
+	}
+
+	for name, config := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			diags := validateResourceConfig(t, "multicdn_cdn_config", hclResourceConfig(t, config, "multicdn_cdn_config"))
+			expectDiagnostic(t, diags, 0, "", "")
+		})
+	}
+}
+
 // Basic acceptance test for CDN config resource
 func TestAccCdnConfigResource_basic(t *testing.T) {
-	mockServer, mockCdnConfigs, factories := setupCdnAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -213,13 +246,13 @@ func TestAccCdnConfigResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "resource_id", "12345"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "content_type", "application/json"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "description", "Test Description"),
-					resource.TestCheckNoResourceAttr("multicdn_cdn_config.test", "version"),
-					resource.TestCheckNoResourceAttr("multicdn_cdn_config.test", "last_updated"),
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "version", "1"),
+					resource.TestCheckResourceAttrSet("multicdn_cdn_config.test", "last_updated"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "cdns.#", "2"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "cdns.0.cdn_name", "cdn1"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "cdns.0.description", "Primary CDN"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "cdns.1.cdn_name", "cdn2"),
-					testAccCheckCdnConfigExists(12345, mockCdnConfigs),
+					testAccCheckCdnConfigExists(12345, mockServer),
 				),
 			},
 			// Update testing
@@ -227,7 +260,15 @@ func TestAccCdnConfigResource_basic(t *testing.T) {
 				Config: testAccCdnResourceConfig(mockServer.URL, "Updated Description"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "description", "Updated Description"),
-					testAccCheckCdnConfigDescription(12345, "Updated Description", mockCdnConfigs),
+					testAccCheckCdnConfigDescription(12345, "Updated Description", mockServer),
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "version", "2"),
+				),
+			},
+			// Applying the same configuration again changes nothing and keeps the values set by the API
+			{
+				Config: testAccCdnResourceConfig(mockServer.URL, "Updated Description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckCdnConfigLastUpdated("multicdn_cdn_config.test", 12345, mockServer),
 				),
 			},
 			// Import testing
@@ -244,7 +285,7 @@ func TestAccCdnConfigResource_basic(t *testing.T) {
 
 // Test for error cases
 func TestAccCdnConfigResource_errors(t *testing.T) {
-	mockServer, _, factories := setupCdnAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -259,9 +300,224 @@ func TestAccCdnConfigResource_errors(t *testing.T) {
 	})
 }
 
+// Test that a configuration that already exists is only adopted when the provider allows it
+func TestAccCdnConfigResource_adoptExisting(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	version := "7"
+	description := "Created elsewhere"
+	mockServer.SetCdnConfig(cdnclient.CdnConfigurationResponse{ResourceID: 12345, Version: &version, Description: &description})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			// Without adopt_existing, the conflict is reported and the configuration is left alone
+			{
+				Config:      testAccCdnResourceConfig(mockServer.URL, "Adopted Description"),
+				ExpectError: regexp.MustCompile(`(?s)Configuration Already Exists.*A CDN configuration with ID\s+12345\s+already\s+exists`),
+			},
+			// With adopt_existing, the configuration is overwritten and recorded in state
+			{
+				Config: strings.Replace(testAccCdnResourceConfig(mockServer.URL, "Adopted Description"),
+					`provider "multicdn" {`, `provider "multicdn" {
+  adopt_existing = true`, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckCdnConfigDescription(12345, "Adopted Description", mockServer),
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "description", "Adopted Description"),
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "version", "8"),
+				),
+			},
+		},
+	})
+}
+
+// Test that a create conflicting with an existing CDN configuration fails, or adopts it with adopt_existing
+func TestCdnConfigResource_adoptExisting(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name          string
+		adoptExisting string
+		expectError   string
+		expectWarning string
+	}{
+		{
+			name:        "default",
+			expectError: "A CDN configuration with ID 12345 already exists",
+		},
+		{
+			name:          "adopted",
+			adoptExisting: `"adopt_existing": true`,
+			expectWarning: `A CDN configuration with ID 12345 already existed, so it was adopted instead of created: its previous version "7"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			version := "7"
+			description := "Created elsewhere"
+			mockServer.SetCdnConfig(cdnclient.CdnConfigurationResponse{ResourceID: 12345, Version: &version, Description: &description})
+
+			server := configureTestProvider(t, mockServer.URL, tc.adoptExisting)
+
+			config := jsonValue(testCdnValidationConfig(testValidationCdns, testValidationEnablementMap, testValidationTrafficOptions))
+			applyResp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "multicdn_cdn_config",
+				PriorState:   jsonValue("null"),
+				PlannedState: config,
+				Config:       config,
+			})
+			if err != nil {
+				t.Fatalf("ApplyResourceChange returned an error: %v", err)
+			}
+			if len(applyResp.Diagnostics) != 1 {
+				t.Fatalf("Expected a single diagnostic, got %v", applyResp.Diagnostics)
+			}
+
+			diagnostic := applyResp.Diagnostics[0]
+			stored, _ := mockServer.CdnConfig(12345)
+			if tc.expectError != "" {
+				if diagnostic.Severity != tfprotov6.DiagnosticSeverityError || !strings.Contains(diagnostic.Detail, tc.expectError) {
+					t.Fatalf("Expected an error containing %q, got %s: %s", tc.expectError, diagnostic.Summary, diagnostic.Detail)
+				}
+				if stored.Description == nil || *stored.Description != description || len(stored.Cdns) != 0 {
+					t.Errorf("Expected the existing configuration to be left unchanged, got %+v", stored)
+				}
+				return
+			}
+
+			if diagnostic.Severity != tfprotov6.DiagnosticSeverityWarning || !strings.Contains(diagnostic.Detail, tc.expectWarning) {
+				t.Fatalf("Expected a warning containing %q, got %s: %s", tc.expectWarning, diagnostic.Summary, diagnostic.Detail)
+			}
+			if applyResp.NewState == nil {
+				t.Errorf("Expected the adopted configuration to be recorded in state")
+			}
+			if stored.Version == nil || *stored.Version != "8" || len(stored.Cdns) != 2 {
+				t.Errorf("Expected the existing version 7 to be overwritten with the planned configuration, got %+v", stored)
+			}
+		})
+	}
+}
+
+// Test that a protected configuration is only destroyed after deletion_protection is disabled in an apply
+func TestAccCdnConfigResource_deletionProtection(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	withDeletionProtection := func(enabled bool) string {
+		return strings.Replace(testAccCdnResourceConfig(mockServer.URL, "Test Description"),
+			"resource_id = 12345", fmt.Sprintf("resource_id = 12345\n  deletion_protection = %t", enabled), 1)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: withDeletionProtection(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "deletion_protection", "true"),
+				),
+			},
+			// Destroying the protected configuration fails and leaves it in place
+			{
+				Config:      withDeletionProtection(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			// Once disabled, the configuration is destroyed at the end of the test
+			{
+				Config: withDeletionProtection(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckCdnConfigExists(12345, mockServer),
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+// Test that deleting a CDN configuration is refused while deletion protection is enabled, without TF_ACC
+func TestCdnConfigResource_deletionProtection(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name               string
+		providerDefault    string
+		deletionProtection string
+		expectError        bool
+	}{
+		{
+			name:               "unprotected",
+			deletionProtection: "false",
+		},
+		{
+			name:               "protected",
+			deletionProtection: "true",
+			expectError:        true,
+		},
+		{
+			name:            "provider_default",
+			providerDefault: `"deletion_protection": true`,
+			expectError:     true,
+		},
+		{
+			name:               "disabled_despite_provider_default",
+			providerDefault:    `"deletion_protection": true`,
+			deletionProtection: "false",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			version := "1"
+			mockServer.SetCdnConfig(cdnclient.CdnConfigurationResponse{ResourceID: 12345, Version: &version})
+
+			server := configureTestProvider(t, mockServer.URL, tc.providerDefault)
+
+			deletionProtection := "null"
+			if tc.deletionProtection != "" {
+				deletionProtection = tc.deletionProtection
+			}
+			config := testCdnValidationConfig(testValidationCdns, testValidationEnablementMap, testValidationTrafficOptions)
+			state := jsonValue(strings.Replace(config, `"resource_id": 12345`, `"resource_id": 12345, "deletion_protection": `+deletionProtection, 1))
+
+			// The delete is refused based on the prior state
+			applyResp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "multicdn_cdn_config",
+				PriorState:   state,
+				PlannedState: jsonValue("null"),
+				Config:       jsonValue("null"),
+			})
+			if err != nil {
+				t.Fatalf("ApplyResourceChange returned an error: %v", err)
+			}
+
+			_, exists := mockServer.CdnConfig(12345)
+			if tc.expectError {
+				if len(applyResp.Diagnostics) != 1 || applyResp.Diagnostics[0].Summary != "Deletion Protection Enabled" {
+					t.Fatalf("Expected a deletion protection error, got %v", applyResp.Diagnostics)
+				}
+				if !exists {
+					t.Error("Expected the protected CDN configuration to be kept")
+				}
+			} else {
+				if len(applyResp.Diagnostics) > 0 {
+					t.Fatalf("Unexpected diagnostics: %v", applyResp.Diagnostics)
+				}
+				if exists {
+					t.Error("Expected the CDN configuration to be deleted")
+				}
+			}
+		})
+	}
+}
+
 // Test for complete workflow
 func TestAccCdnConfigResource_completeLifecycle(t *testing.T) {
-	mockServer, mockCdnConfigs, factories := setupCdnAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -271,7 +527,7 @@ func TestAccCdnConfigResource_completeLifecycle(t *testing.T) {
 			{
 				Config: testAccCdnResourceConfig(mockServer.URL, "Initial Config"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckCdnConfigExists(12345, mockCdnConfigs),
+					testAccCheckCdnConfigExists(12345, mockServer),
 				),
 			},
 			// Verify import works
@@ -293,13 +549,13 @@ func TestAccCdnConfigResource_completeLifecycle(t *testing.T) {
 			{
 				Config: fmt.Sprintf(`
 provider "multicdn" {
-  api_key = "api_key"
-  api_secret = "api_secret"
+  api_key = "test-key"
+  api_secret = "test-secret"
   base_url = "%s"
 }
 `, mockServer.URL),
 				Check: func(s *terraform.State) error {
-					if _, exists := mockCdnConfigs[12345]; exists {
+					if _, exists := mockServer.CdnConfig(12345); exists {
 						return fmt.Errorf("CDN configuration with ID 12345 still exists after destroy")
 					}
 					return nil
@@ -311,7 +567,7 @@ provider "multicdn" {
 
 // Test for complete configuration with all nested fields
 func TestAccCdnConfigResource_comprehensive(t *testing.T) {
-	mockServer, mockCdnConfigs, factories := setupCdnAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -321,12 +577,12 @@ func TestAccCdnConfigResource_comprehensive(t *testing.T) {
 			{
 				Config: testAccCdnResourceConfigComprehensive(mockServer.URL, "Comprehensive Config"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckCdnConfigExists(54321, mockCdnConfigs),
+					testAccCheckCdnConfigExists(54321, mockServer),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "resource_id", "54321"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "content_type", "application/json"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "description", "Comprehensive Config"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "version", "1.0"),
-					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "last_updated", "2025-08-01T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("multicdn_cdn_config.comprehensive", "last_updated"),
 					// Check CDN entries
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "cdns.#", "3"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "cdns.0.cdn_name", "cdn1"),
@@ -335,7 +591,7 @@ func TestAccCdnConfigResource_comprehensive(t *testing.T) {
 					// Check enablement map
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "cdn_enablement_map.world_default.#", "2"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "cdn_enablement_map.asn_overrides.12345.#", "1"),
-					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "cdn_enablement_map.asn_overrides.67890.#", "2"),
+					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "cdn_enablement_map.asn_overrides.36890.#", "2"),
 					// Check traffic distribution
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "traffic_distribution.world_default.options.#", "2"),
 				),
@@ -344,10 +600,10 @@ func TestAccCdnConfigResource_comprehensive(t *testing.T) {
 			{
 				Config: testAccCdnResourceConfigComprehensiveUpdated(mockServer.URL, "Updated Comprehensive Config"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckCdnConfigExists(54321, mockCdnConfigs),
+					testAccCheckCdnConfigExists(54321, mockServer),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "description", "Updated Comprehensive Config"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "version", "1.1"),
-					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "last_updated", "2025-08-02T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("multicdn_cdn_config.comprehensive", "last_updated"),
 					// Check updated CDN entries
 					resource.TestCheckResourceAttr("multicdn_cdn_config.comprehensive", "cdns.0.description", "Updated Primary CDN"),
 					// Check updated enablement map
@@ -362,7 +618,7 @@ func TestAccCdnConfigResource_comprehensive(t *testing.T) {
 
 // Test for minimal configuration with only required fields
 func TestAccCdnConfigResource_minimal(t *testing.T) {
-	mockServer, mockCdnConfigs, factories := setupCdnAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -372,7 +628,7 @@ func TestAccCdnConfigResource_minimal(t *testing.T) {
 			{
 				Config: testAccCdnResourceConfigMinimal(mockServer.URL),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckCdnConfigExists(98765, mockCdnConfigs),
+					testAccCheckCdnConfigExists(98765, mockServer),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.minimal", "resource_id", "98765"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.minimal", "cdns.#", "1"),
 					resource.TestCheckResourceAttr("multicdn_cdn_config.minimal", "cdns.0.cdn_name", "minimal-cdn"),
@@ -384,12 +640,89 @@ func TestAccCdnConfigResource_minimal(t *testing.T) {
 	})
 }
 
+// Test that a configuration deleted outside of Terraform is planned for re-creation
+func TestAccCdnConfigResource_deletedOutOfBand(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			// Create the configuration
+			{
+				Config: testAccCdnResourceConfig(mockServer.URL, "Test Description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckCdnConfigExists(12345, mockServer),
+				),
+			},
+			// Remove it from the backend and expect a plan to re-create it
+			{
+				PreConfig: func() {
+					mockServer.DeleteCdnConfig(12345)
+				},
+				Config:             testAccCdnResourceConfig(mockServer.URL, "Test Description"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Apply re-creates the configuration
+			{
+				Config: testAccCdnResourceConfig(mockServer.URL, "Test Description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckCdnConfigExists(12345, mockServer),
+				),
+			},
+			// Destroy succeeds even if the configuration is already gone
+			{
+				PreConfig: func() {
+					mockServer.DeleteCdnConfig(12345)
+				},
+				Config:  testAccCdnResourceConfig(mockServer.URL, "Test Description"),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+// Test that a CDN configuration deleted outside of Terraform is removed from state on read, and that
+// destroying it succeeds, without TF_ACC
+func TestCdnConfigResource_deletedOutOfBand(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	mockServer.DeleteCdnConfig(12345)
+
+	ctx := context.Background()
+	server := configureTestProvider(t, mockServer.URL, "")
+	state := jsonValue(testCdnValidationConfig(testValidationCdns, testValidationEnablementMap, testValidationTrafficOptions))
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "multicdn_cdn_config",
+		CurrentState: state,
+	})
+	if err != nil || len(readResp.Diagnostics) > 0 {
+		t.Fatalf("Failed to read the CDN configuration: %v %v", err, readResp.Diagnostics)
+	}
+	if refreshed := decodeResourceValue(t, server, "multicdn_cdn_config", readResp.NewState); !refreshed.IsNull() {
+		t.Errorf("Expected the deleted CDN configuration to be removed from state, got %v", refreshed)
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "multicdn_cdn_config",
+		PriorState:   state,
+		PlannedState: jsonValue("null"),
+		Config:       jsonValue("null"),
+	})
+	if err != nil || len(applyResp.Diagnostics) > 0 {
+		t.Fatalf("Expected destroying the deleted CDN configuration to succeed: %v %v", err, applyResp.Diagnostics)
+	}
+}
+
 // Configuration for comprehensive test with all nested fields
 func testAccCdnResourceConfigComprehensive(serverURL, description string) string {
 	return fmt.Sprintf(`
 provider "multicdn" {
-  api_key = "api_key"
-  api_secret = "api_secret"
+  api_key = "test-key"
+  api_secret = "test-secret"
   base_url = "%s"
 }
 
@@ -398,7 +731,6 @@ resource "multicdn_cdn_config" "comprehensive" {
   content_type = "application/json"
   description = "%s"
   version = "1.0"
-  last_updated = "2025-08-01T00:00:00Z"
 
   // Three CDN entries
   cdns = [
@@ -406,19 +738,19 @@ resource "multicdn_cdn_config" "comprehensive" {
       cdn_name = "cdn1"
       description = "Primary CDN"
       fqdn = "cdn1.example.com"
-      client_cdn_id = "cdn1_id"
+      client_cdn_id = "cdn1"
     },
     {
       cdn_name = "cdn2"
       description = "Secondary CDN"
       fqdn = "cdn2.example.com"
-      client_cdn_id = "cdn2_id"
+      client_cdn_id = "cdn2"
     },
     {
       cdn_name = "cdn3"
       description = "Tertiary CDN"
       fqdn = "cdn3.example.com"
-      client_cdn_id = "cdn3_id"
+      client_cdn_id = "cdn3"
     }
   ]
   
@@ -428,7 +760,7 @@ resource "multicdn_cdn_config" "comprehensive" {
     
     asn_overrides = {
       "12345" = ["cdn1"]
-      "67890" = ["cdn2", "cdn3"]
+      "36890" = ["cdn2", "cdn3"]
     }
     
     continents = {
@@ -461,7 +793,7 @@ resource "multicdn_cdn_config" "comprehensive" {
           "CA" = {
             default = ["cdn2", "cdn3"]
    			asn_overrides = {
-              "33440" = ["cdn4"]
+              "33440" = ["cdn1"]
               "24680" = ["cdn3", "cdn2"]
             }
           }
@@ -474,14 +806,14 @@ resource "multicdn_cdn_config" "comprehensive" {
           "DE" = {
             default = ["cdn1", "cdn3"]
 			asn_overrides = {
-              "33440" = ["cdn4"]
+              "33440" = ["cdn1"]
               "24680" = ["cdn3", "cdn2"]
             }
           }
           "FR" = {
             default = ["cdn2"]
 			asn_overrides = {
-              "33440" = ["cdn4"]
+              "33440" = ["cdn1"]
               "24680" = ["cdn3", "cdn2"]
             }
           }
@@ -636,8 +968,8 @@ resource "multicdn_cdn_config" "comprehensive" {
 func testAccCdnResourceConfigComprehensiveUpdated(serverURL, description string) string {
 	return fmt.Sprintf(`
 provider "multicdn" {
-  api_key = "api_key"
-  api_secret = "api_secret"
+  api_key = "test-key"
+  api_secret = "test-secret"
   base_url = "%s"
 }
 
@@ -646,7 +978,6 @@ resource "multicdn_cdn_config" "comprehensive" {
   content_type = "application/json"
   description = "%s"
   version = "1.1"
-  last_updated = "2025-08-02T00:00:00Z"
   
   // Updated CDN entries
   cdns = [
@@ -654,19 +985,19 @@ resource "multicdn_cdn_config" "comprehensive" {
       cdn_name = "cdn1"
       description = "Updated Primary CDN"
       fqdn = "cdn1.example.com"
-      client_cdn_id = "cdn1_id"
+      client_cdn_id = "cdn1"
     },
     {
       cdn_name = "cdn2"
       description = "Updated Secondary CDN"
       fqdn = "cdn2.example.com"
-      client_cdn_id = "cdn2_id"
+      client_cdn_id = "cdn2"
     },
     {
       cdn_name = "cdn3"
       description = "Updated Tertiary CDN"
       fqdn = "cdn3.example.com"
-      client_cdn_id = "cdn3_id"
+      client_cdn_id = "cdn3"
     }
   ]
   
@@ -676,7 +1007,7 @@ resource "multicdn_cdn_config" "comprehensive" {
     
     asn_overrides = {
       "12345" = ["cdn1", "cdn3"]  // Added cdn3
-      "67890" = ["cdn2"]          // Removed cdn3
+      "36890" = ["cdn2"]          // Removed cdn3
     }
     
     continents = {
@@ -710,7 +1041,7 @@ resource "multicdn_cdn_config" "comprehensive" {
             default = ["cdn1"]  // Changed from cdn2, cdn3
 			asn_overrides = {
 			  "12345" = ["cdn1"]
-			  "67890" = ["cdn2", "cdn3"]
+			  "36890" = ["cdn2", "cdn3"]
 			}
           }
         }
@@ -723,14 +1054,14 @@ resource "multicdn_cdn_config" "comprehensive" {
             default = ["cdn2", "cdn3"]  // Changed from cdn1, cdn3
 			asn_overrides = {
 			  "12345" = ["cdn1"]
-			  "67890" = ["cdn2", "cdn3"]
+			  "36890" = ["cdn2", "cdn3"]
 			}
           }
           "FR" = {
             default = ["cdn3"]  // Changed from cdn2
 			asn_overrides = {
 			  "12345" = ["cdn1"]
-			  "67890" = ["cdn2", "cdn3"]
+			  "36890" = ["cdn2", "cdn3"]
     		}
           }
         }
@@ -865,8 +1196,8 @@ resource "multicdn_cdn_config" "comprehensive" {
 func testAccCdnResourceConfigMinimal(serverURL string) string {
 	return fmt.Sprintf(`
 provider "multicdn" {
-  api_key = "api_key"
-  api_secret = "api_secret"
+  api_key = "test-key"
+  api_secret = "test-secret"
   base_url = "%s"
 }
 
@@ -884,13 +1215,13 @@ resource "multicdn_cdn_config" "minimal" {
   
   // Minimal required enablement map
   cdn_enablement_map = {
-    world_default = ["minimal-cdn"]
+    world_default = ["minimal-id"]
 	asn_overrides = {
-	  "12345" = ["minimal-cdn"]
+	  "12345" = ["minimal-id"]
 	}
 	continents = {
 	  "NA" = {
-	    default = ["minimal-cdn"]
+	    default = ["minimal-id"]
       }
 	}
   }
@@ -903,7 +1234,7 @@ resource "multicdn_cdn_config" "minimal" {
           name = "minimal-option"
           distribution = [
             {
-              id = "minimal-cdn"
+              id = "minimal-id"
               weight = 100
             }
           ]
@@ -0,0 +1,432 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
+	"github.com/constellix/terraform-provider-constellix-multicdn/internal/asn"
+)
+
+// totalTrafficWeight is the sum the weights of a traffic option must reach unless traffic is distributed equally
+const totalTrafficWeight = 100
+
+// ValidateConfig checks the CDN configuration for inconsistencies the API would only report after apply starts,
+// such as traffic weights not summing up, references to CDNs missing from cdns or unknown geographic codes
+func (r *cdnResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config cdnResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		// Whole collections or objects are still unknown, they are validated once known
+		return
+	}
+
+	validateCdnEntries(config.Cdns, &resp.Diagnostics)
+
+	cdnIDs := knownClientCdnIDs(config.Cdns)
+	validateCdnEnablementMap(config.CdnEnablementMap, cdnIDs, &resp.Diagnostics)
+	validateTrafficDistribution(config.TrafficDistribution, cdnIDs, &resp.Diagnostics)
+}
+
+// ModifyPlan plans the default deletion protection, marks the attributes set by the API as unknown when the
+// configuration is updated, and checks the planned configuration against provider settings and the preference
+// configuration of the same resource, which are not available to ValidateConfig
+func (r *cdnResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, r.client, req, resp)
+	markAPIManagedAttributesUnknown(ctx, req, resp)
+
+	// Nothing to check when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan cdnResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	if diags.HasError() {
+		return
+	}
+
+	if !r.client.allowReservedASNs {
+		validatePublicASNs(plan.CdnEnablementMap, &resp.Diagnostics)
+	}
+
+	r.checkSubdivisionCountries(ctx, &plan, &resp.Diagnostics)
+}
+
+// checkSubdivisionCountries warns about subdivision overrides for countries that are not listed in the
+// enabled_subdivision_countries of the preference configuration with the same resource_id, since the API
+// ignores them. The preference may be changed in the same apply, so this is not an error.
+func (r *cdnResource) checkSubdivisionCountries(ctx context.Context, plan *cdnResourceModel, diags *diag.Diagnostics) {
+	if plan.CdnEnablementMap == nil || plan.ResourceID.IsNull() || plan.ResourceID.IsUnknown() {
+		return
+	}
+
+	type subdivisionCountry struct {
+		code string
+		path path.Path
+	}
+	var countries []subdivisionCountry
+	mapPath := path.Root("cdn_enablement_map")
+	for _, continentCode := range slices.Sorted(maps.Keys(plan.CdnEnablementMap.Continents)) {
+		continent := plan.CdnEnablementMap.Continents[continentCode]
+		if continent == nil {
+			continue
+		}
+		for _, countryCode := range slices.Sorted(maps.Keys(continent.Countries)) {
+			if country := continent.Countries[countryCode]; country != nil && len(country.Subdivisions) > 0 {
+				countries = append(countries, subdivisionCountry{
+					code: countryCode,
+					path: mapPath.AtName("continents").AtMapKey(continentCode).AtName("countries").AtMapKey(countryCode).AtName("subdivisions"),
+				})
+			}
+		}
+	}
+	if len(countries) == 0 {
+		return
+	}
+
+	resourceID := plan.ResourceID.ValueInt64()
+	enabled, err := r.client.preference.GetEnabledSubdivisionCountries(ctx, resourceID)
+	if err != nil && !response.IsNotFound(err) {
+		diags.AddWarning(
+			"Unable to Verify Subdivision Overrides",
+			fmt.Sprintf("Could not read the enabled subdivision countries of preference configuration %d: %s", resourceID, err),
+		)
+		return
+	}
+
+	enabledCountries := make(map[string]struct{})
+	if enabled != nil {
+		for _, continent := range enabled.Continents {
+			for _, country := range continent.Countries {
+				enabledCountries[country] = struct{}{}
+			}
+		}
+	}
+
+	for _, country := range countries {
+		if _, ok := enabledCountries[country.code]; ok {
+			continue
+		}
+
+		reason := fmt.Sprintf("the preference configuration with resource_id %d does not list it in enabled_subdivision_countries", resourceID)
+		if enabled == nil {
+			reason = fmt.Sprintf("no preference configuration with resource_id %d exists", resourceID)
+		}
+		diags.AddAttributeWarning(
+			country.path,
+			"Subdivision Overrides Not Enabled",
+			fmt.Sprintf("Subdivision overrides for %s are ignored by the API because %s. "+
+				"Add %s to enabled_subdivision_countries of the multicdn_preference_config for the same resource_id.", country.code, reason, country.code),
+		)
+	}
+}
+
+// validateCdnEntries checks that no two CDN entries share a client CDN identifier
+func validateCdnEntries(cdns []cdnEntryModel, diags *diag.Diagnostics) {
+	firstIndex := make(map[string]int, len(cdns))
+	for i, cdn := range cdns {
+		if cdn.ClientCdnID.IsNull() || cdn.ClientCdnID.IsUnknown() {
+			continue
+		}
+
+		id := cdn.ClientCdnID.ValueString()
+		if first, ok := firstIndex[id]; ok {
+			diags.AddAttributeError(
+				path.Root("cdns").AtListIndex(i).AtName("client_cdn_id"),
+				"Duplicate Client CDN Identifier",
+				fmt.Sprintf("client_cdn_id %q is already used by cdns[%d]. Client CDN identifiers must be unique.", id, first),
+			)
+			continue
+		}
+		firstIndex[id] = i
+	}
+}
+
+// validateCdnEnablementMap checks that every enabled list of the enablement map is non-empty
+// and only references declared CDNs, and that all geographic keys are known codes.
+// A nil cdnIDs set skips the reference check.
+func validateCdnEnablementMap(enablementMap *cdnEnablementMapModel, cdnIDs map[string]struct{}, diags *diag.Diagnostics) {
+	if enablementMap == nil {
+		return
+	}
+
+	mapPath := path.Root("cdn_enablement_map")
+	validateEnabledCdns(enablementMap.WorldDefault, mapPath.AtName("world_default"), cdnIDs, diags)
+	validateASNOverrides(enablementMap.ASNOverrides, mapPath.AtName("asn_overrides"), cdnIDs, diags)
+
+	for _, continentCode := range slices.Sorted(maps.Keys(enablementMap.Continents)) {
+		continent := enablementMap.Continents[continentCode]
+		if continent == nil {
+			continue
+		}
+		continentPath := mapPath.AtName("continents").AtMapKey(continentCode)
+		validateContinentCode(continentCode, continentPath, diags)
+		validateEnabledCdns(continent.Default, continentPath.AtName("default"), cdnIDs, diags)
+
+		for _, countryCode := range slices.Sorted(maps.Keys(continent.Countries)) {
+			country := continent.Countries[countryCode]
+			countryPath := continentPath.AtName("countries").AtMapKey(countryCode)
+			validateCountryCode(continentCode, countryCode, countryPath, diags)
+			if country == nil {
+				continue
+			}
+			validateEnabledCdns(country.Default, countryPath.AtName("default"), cdnIDs, diags)
+			validateASNOverrides(country.ASNOverrides, countryPath.AtName("asn_overrides"), cdnIDs, diags)
+
+			for _, subdivisionCode := range slices.Sorted(maps.Keys(country.Subdivisions)) {
+				subdivision := country.Subdivisions[subdivisionCode]
+				subdivisionPath := countryPath.AtName("subdivisions").AtMapKey(subdivisionCode)
+				validateSubdivisionCode(countryCode, subdivisionCode, subdivisionPath, diags)
+				if subdivision == nil {
+					continue
+				}
+				validateASNOverrides(subdivision.ASNOverrides, subdivisionPath.AtName("asn_overrides"), cdnIDs, diags)
+			}
+		}
+	}
+}
+
+// validateASNOverrides validates the ASN keys and the enabled CDN list of every ASN override.
+// Keys that normalize to the same ASN, such as "AS13335" and "13335", are reported as duplicates.
+func validateASNOverrides(overrides map[string][]types.String, overridesPath path.Path, cdnIDs map[string]struct{}, diags *diag.Diagnostics) {
+	seen := make(map[uint32]string, len(overrides))
+	for _, key := range slices.Sorted(maps.Keys(overrides)) {
+		keyPath := overridesPath.AtMapKey(key)
+
+		number, err := asn.Parse(key)
+		switch {
+		case err != nil:
+			diags.AddAttributeError(keyPath, "Invalid ASN", "The asn_overrides key is an "+err.Error()+".")
+		case seen[number] != "":
+			diags.AddAttributeError(
+				keyPath,
+				"Duplicate ASN",
+				fmt.Sprintf("%q and %q both refer to AS%d. Keep only one of them.", seen[number], key, number),
+			)
+		default:
+			seen[number] = key
+		}
+
+		validateEnabledCdns(overrides[key], keyPath, cdnIDs, diags)
+	}
+}
+
+// validatePublicASNs reports every ASN override key in the enablement map that is
+// reserved, documentation-only or private-use
+func validatePublicASNs(enablementMap *cdnEnablementMapModel, diags *diag.Diagnostics) {
+	walkASNOverrides(enablementMap, func(overrides map[string][]types.String, overridesPath path.Path) {
+		for _, key := range slices.Sorted(maps.Keys(overrides)) {
+			number, err := asn.Parse(key)
+			if err != nil {
+				// Already reported by ValidateConfig
+				continue
+			}
+			if reason := asn.Reserved(number); reason != "" {
+				diags.AddAttributeError(
+					overridesPath.AtMapKey(key),
+					"Reserved ASN",
+					fmt.Sprintf("AS%d is %s and never originates public traffic. Set allow_reserved_asns in the provider configuration to use it anyway.", number, reason),
+				)
+			}
+		}
+	})
+}
+
+// walkASNOverrides calls fn for the ASN overrides of the world, every country and every subdivision
+func walkASNOverrides(enablementMap *cdnEnablementMapModel, fn func(overrides map[string][]types.String, overridesPath path.Path)) {
+	if enablementMap == nil {
+		return
+	}
+
+	mapPath := path.Root("cdn_enablement_map")
+	fn(enablementMap.ASNOverrides, mapPath.AtName("asn_overrides"))
+
+	for _, continentCode := range slices.Sorted(maps.Keys(enablementMap.Continents)) {
+		continent := enablementMap.Continents[continentCode]
+		if continent == nil {
+			continue
+		}
+		continentPath := mapPath.AtName("continents").AtMapKey(continentCode)
+
+		for _, countryCode := range slices.Sorted(maps.Keys(continent.Countries)) {
+			country := continent.Countries[countryCode]
+			if country == nil {
+				continue
+			}
+			countryPath := continentPath.AtName("countries").AtMapKey(countryCode)
+			fn(country.ASNOverrides, countryPath.AtName("asn_overrides"))
+
+			for _, subdivisionCode := range slices.Sorted(maps.Keys(country.Subdivisions)) {
+				subdivision := country.Subdivisions[subdivisionCode]
+				if subdivision == nil {
+					continue
+				}
+				fn(subdivision.ASNOverrides, countryPath.AtName("subdivisions").AtMapKey(subdivisionCode).AtName("asn_overrides"))
+			}
+		}
+	}
+}
+
+// validateEnabledCdns checks that a configured list of enabled CDNs is non-empty and only references declared CDNs
+func validateEnabledCdns(enabled []types.String, listPath path.Path, cdnIDs map[string]struct{}, diags *diag.Diagnostics) {
+	// A nil list is not configured, an empty one enables no CDN at all
+	if enabled == nil {
+		return
+	}
+	if len(enabled) == 0 {
+		diags.AddAttributeError(
+			listPath,
+			"Empty CDN List",
+			"At least one CDN must be enabled. List the client_cdn_id of at least one entry in cdns.",
+		)
+		return
+	}
+
+	if cdnIDs == nil {
+		return
+	}
+	for i, id := range enabled {
+		if id.IsNull() || id.IsUnknown() {
+			continue
+		}
+		if _, ok := cdnIDs[id.ValueString()]; !ok {
+			diags.AddAttributeError(
+				listPath.AtListIndex(i),
+				"Unknown CDN Identifier",
+				unknownCdnIDDetail("Enabled CDN", id.ValueString(), cdnIDs),
+			)
+		}
+	}
+}
+
+// validateTrafficDistribution validates every traffic option list and geographic key of the traffic distribution.
+// A nil cdnIDs set skips the reference check.
+func validateTrafficDistribution(distribution *trafficDistributionModel, cdnIDs map[string]struct{}, diags *diag.Diagnostics) {
+	if distribution == nil {
+		return
+	}
+
+	distributionPath := path.Root("traffic_distribution")
+
+	if distribution.WorldDefault != nil {
+		validateTrafficOptions(distribution.WorldDefault.Options, distributionPath.AtName("world_default").AtName("options"), cdnIDs, diags)
+	}
+
+	for _, continentCode := range slices.Sorted(maps.Keys(distribution.Continents)) {
+		continent := distribution.Continents[continentCode]
+		continentPath := distributionPath.AtName("continents").AtMapKey(continentCode)
+		validateContinentCode(continentCode, continentPath, diags)
+		if continent == nil {
+			continue
+		}
+
+		if continent.Default != nil {
+			validateTrafficOptions(continent.Default.Options, continentPath.AtName("default").AtName("options"), cdnIDs, diags)
+		}
+
+		for _, countryCode := range slices.Sorted(maps.Keys(continent.Countries)) {
+			country := continent.Countries[countryCode]
+			countryPath := continentPath.AtName("countries").AtMapKey(countryCode)
+			validateCountryCode(continentCode, countryCode, countryPath, diags)
+			if country == nil || country.Default == nil {
+				continue
+			}
+			validateTrafficOptions(country.Default.Options, countryPath.AtName("default").AtName("options"), cdnIDs, diags)
+		}
+	}
+}
+
+// validateTrafficOptions validates the weights and CDN references of each traffic option in the list.
+// A nil cdnIDs set skips the reference check.
+func validateTrafficOptions(options []trafficOptionModel, optionsPath path.Path, cdnIDs map[string]struct{}, diags *diag.Diagnostics) {
+	for i, option := range options {
+		optionPath := optionsPath.AtListIndex(i)
+		distributionPath := optionPath.AtName("distribution")
+
+		// Weights can only be checked once it is known how traffic is distributed
+		checkWeights := !option.EqualWeight.IsUnknown()
+		equalWeight := option.EqualWeight.ValueBool()
+
+		var sum int64
+		sumComplete := true
+		for j, entry := range option.Distribution {
+			entryPath := distributionPath.AtListIndex(j)
+
+			if cdnIDs != nil && !entry.ID.IsNull() && !entry.ID.IsUnknown() {
+				if _, ok := cdnIDs[entry.ID.ValueString()]; !ok {
+					diags.AddAttributeError(
+						entryPath.AtName("id"),
+						"Unknown CDN Identifier",
+						unknownCdnIDDetail("Distribution id", entry.ID.ValueString(), cdnIDs),
+					)
+				}
+			}
+
+			switch {
+			case entry.Weight.IsUnknown():
+				sumComplete = false
+			case entry.Weight.IsNull():
+				sumComplete = false
+				if checkWeights && !equalWeight {
+					diags.AddAttributeError(
+						entryPath.AtName("weight"),
+						"Missing Traffic Weight",
+						"A weight is required for every distribution entry when equal_weight is false.",
+					)
+				}
+			case entry.Weight.ValueInt64() < 0:
+				diags.AddAttributeError(
+					entryPath.AtName("weight"),
+					"Invalid Traffic Weight",
+					fmt.Sprintf("Traffic weights must not be negative, got %d.", entry.Weight.ValueInt64()),
+				)
+				sumComplete = false
+			case equalWeight:
+				diags.AddAttributeWarning(
+					entryPath.AtName("weight"),
+					"Ignored Traffic Weight",
+					"Traffic is distributed equally because equal_weight is true, so this weight is ignored. Remove it or set equal_weight to false.",
+				)
+			default:
+				sum += entry.Weight.ValueInt64()
+			}
+		}
+
+		if checkWeights && !equalWeight && sumComplete && len(option.Distribution) > 0 && sum != totalTrafficWeight {
+			diags.AddAttributeError(
+				distributionPath,
+				"Invalid Traffic Distribution",
+				fmt.Sprintf("The weights of traffic option %q must sum to %d when equal_weight is false, got %d.",
+					option.Name.ValueString(), totalTrafficWeight, sum),
+			)
+		}
+	}
+}
+
+// unknownCdnIDDetail describes a reference to a CDN that is not declared in cdns
+func unknownCdnIDDetail(subject, id string, cdnIDs map[string]struct{}) string {
+	return fmt.Sprintf("%s %q does not match the client_cdn_id of any entry in cdns. Known identifiers: %s",
+		subject, id, strings.Join(slices.Sorted(maps.Keys(cdnIDs)), ", "))
+}
+
+// knownClientCdnIDs returns the set of client CDN identifiers declared in cdns,
+// or nil when any of them is not known yet
+func knownClientCdnIDs(cdns []cdnEntryModel) map[string]struct{} {
+	ids := make(map[string]struct{}, len(cdns))
+	for _, cdn := range cdns {
+		if cdn.ClientCdnID.IsUnknown() {
+			return nil
+		}
+		ids[cdn.ClientCdnID.ValueString()] = struct{}{}
+	}
+	return ids
+}
@@ -0,0 +1,845 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+)
+
+// Acceptance test for plan-time validation of traffic distribution weights and identifiers
+func TestAccCdnConfigResource_trafficDistributionValidation(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name        string
+		options     string
+		expectError *regexp.Regexp
+	}{
+		{
+			name: "weights_not_summing_to_100",
+			options: `
+        {
+          name = "short"
+          distribution = [
+            { id = "cdn1", weight = 60 },
+            { id = "cdn2", weight = 35 }
+          ]
+        }`,
+			expectError: regexp.MustCompile(`(?s)Invalid Traffic Distribution.*"short" must sum to 100`),
+		},
+		{
+			name: "negative_weight",
+			options: `
+        {
+          name = "negative"
+          equal_weight = false
+          distribution = [
+            { id = "cdn1", weight = 110 },
+            { id = "cdn2", weight = -10 }
+          ]
+        }`,
+			expectError: regexp.MustCompile(`(?s)Invalid Traffic Weight.*must not be negative, got -10`),
+		},
+		{
+			name: "missing_weight",
+			options: `
+        {
+          name = "missing"
+          distribution = [
+            { id = "cdn1", weight = 100 },
+            { id = "cdn2" }
+          ]
+        }`,
+			expectError: regexp.MustCompile(`(?s)Missing Traffic Weight.*A weight is required`),
+		},
+		{
+			name: "unknown_cdn_id",
+			options: `
+        {
+          name = "typo"
+          equal_weight = true
+          distribution = [
+            { id = "cdn1" },
+            { id = "cnd2" }
+          ]
+        }`,
+			expectError: regexp.MustCompile(`(?s)Unknown CDN Identifier.*Distribution id "cnd2" does not match`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: factories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccCdnResourceConfigWithTrafficOptions(mockServer.URL, tc.options),
+						PlanOnly:    true,
+						ExpectError: tc.expectError,
+					},
+				},
+			})
+		})
+	}
+}
+
+// Test plan-time validation of traffic distribution weights and identifiers without Terraform
+func TestCdnConfigResource_trafficDistributionValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       string
+		expectSummary string
+		expectDetail  string
+		expectWarning bool
+	}{
+		{
+			name:    "valid",
+			options: `{"name": "split", "distribution": [{"id": "cdn1", "weight": 60}, {"id": "cdn2", "weight": 40}]}`,
+		},
+		{
+			name:          "weights_not_summing_to_100",
+			options:       `{"name": "short", "distribution": [{"id": "cdn1", "weight": 60}, {"id": "cdn2", "weight": 35}]}`,
+			expectSummary: "Invalid Traffic Distribution",
+			expectDetail:  `"short" must sum to 100`,
+		},
+		{
+			name:          "negative_weight",
+			options:       `{"name": "negative", "equal_weight": false, "distribution": [{"id": "cdn1", "weight": 110}, {"id": "cdn2", "weight": -10}]}`,
+			expectSummary: "Invalid Traffic Weight",
+			expectDetail:  "must not be negative, got -10",
+		},
+		{
+			name:          "missing_weight",
+			options:       `{"name": "missing", "distribution": [{"id": "cdn1", "weight": 100}, {"id": "cdn2"}]}`,
+			expectSummary: "Missing Traffic Weight",
+			expectDetail:  "A weight is required",
+		},
+		{
+			name:          "unknown_cdn_id",
+			options:       `{"name": "typo", "equal_weight": true, "distribution": [{"id": "cdn1"}, {"id": "cnd2"}]}`,
+			expectSummary: "Unknown CDN Identifier",
+			expectDetail:  `Distribution id "cnd2" does not match`,
+		},
+		{
+			name:          "ignored_equal_weight",
+			options:       `{"name": "equal", "equal_weight": true, "distribution": [{"id": "cdn1", "weight": 10}, {"id": "cdn2"}]}`,
+			expectSummary: "Ignored Traffic Weight",
+			expectWarning: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			severity := tfprotov6.DiagnosticSeverityError
+			if tc.expectWarning {
+				severity = tfprotov6.DiagnosticSeverityWarning
+			}
+
+			diags := validateResourceConfig(t, "multicdn_cdn_config", testCdnValidationConfig(testValidationCdns, testValidationEnablementMap, tc.options))
+			expectDiagnostic(t, diags, severity, tc.expectSummary, tc.expectDetail)
+		})
+	}
+}
+
+// Acceptance test for plan-time validation of the CDN enablement map and CDN entries
+func TestAccCdnConfigResource_enablementMapValidation(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name          string
+		cdns          string
+		enablementMap string
+		expectError   *regexp.Regexp
+	}{
+		{
+			name:          "unknown_world_default_id",
+			cdns:          testAccValidationCdns,
+			enablementMap: `world_default = ["cdn1", "cdn_2"]`,
+			expectError:   regexp.MustCompile(`(?s)Unknown CDN Identifier.*Enabled CDN "cdn_2" does not match`),
+		},
+		{
+			name: "unknown_subdivision_asn_override_id",
+			cdns: testAccValidationCdns,
+			enablementMap: `world_default = ["cdn1"]
+    continents = {
+      "NA" = {
+        countries = {
+          "US" = {
+            subdivisions = {
+              "CA" = {
+                asn_overrides = {
+                  "12345" = ["cdn3"]
+                }
+              }
+            }
+          }
+        }
+      }
+    }`,
+			expectError: regexp.MustCompile(`(?s)Unknown CDN Identifier.*Enabled CDN "cdn3" does not match`),
+		},
+		{
+			name: "empty_continent_default",
+			cdns: testAccValidationCdns,
+			enablementMap: `world_default = ["cdn1"]
+    continents = {
+      "EU" = {
+        default = []
+      }
+    }`,
+			expectError: regexp.MustCompile(`(?s)Empty CDN List.*At least one CDN must be enabled`),
+		},
+		{
+			name: "empty_asn_override",
+			cdns: testAccValidationCdns,
+			enablementMap: `world_default = ["cdn1"]
+    asn_overrides = {
+      "12345" = []
+    }`,
+			expectError: regexp.MustCompile(`(?s)Empty CDN List.*At least one CDN must be enabled`),
+		},
+		{
+			name: "unknown_continent",
+			cdns: testAccValidationCdns,
+			enablementMap: `world_default = ["cdn1"]
+    continents = {
+      "EUR" = {
+        default = ["cdn2"]
+      }
+    }`,
+			expectError: regexp.MustCompile(`(?s)Unknown Continent Code.*"EUR" is not a continent code`),
+		},
+		{
+			name: "country_under_wrong_continent",
+			cdns: testAccValidationCdns,
+			enablementMap: `world_default = ["cdn1"]
+    continents = {
+      "NA" = {
+        countries = {
+          "DE" = {
+            default = ["cdn2"]
+          }
+        }
+      }
+    }`,
+			expectError: regexp.MustCompile(`(?s)Invalid Country Code.*DE \(Germany\) belongs to EU, not NA`),
+		},
+		{
+			name: "unknown_subdivision",
+			cdns: testAccValidationCdns,
+			enablementMap: `world_default = ["cdn1"]
+    continents = {
+      "NA" = {
+        countries = {
+          "US" = {
+            subdivisions = {
+              "ON" = {
+                asn_overrides = {
+                  "12345" = ["cdn2"]
+                }
+              }
+            }
+          }
+        }
+      }
+    }`,
+			expectError: regexp.MustCompile(`(?s)Unknown Subdivision Code.*"ON" is not an ISO 3166-2 subdivision code of US`),
+		},
+		{
+			name: "duplicate_client_cdn_id",
+			cdns: testAccValidationCdns + `,
+    {
+      cdn_name = "cdn1-backup"
+      fqdn = "backup.example.com"
+      client_cdn_id = "cdn1"
+    }`,
+			enablementMap: `world_default = ["cdn1", "cdn2"]`,
+			expectError:   regexp.MustCompile(`(?s)Duplicate Client CDN Identifier.*client_cdn_id "cdn1" is already used by cdns\[0\]`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: factories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccCdnResourceValidationConfig(mockServer.URL, tc.cdns, tc.enablementMap, testAccValidationTrafficOptions),
+						PlanOnly:    true,
+						ExpectError: tc.expectError,
+					},
+				},
+			})
+		})
+	}
+}
+
+// Test plan-time validation of the CDN enablement map and CDN entries without Terraform
+func TestCdnConfigResource_enablementMapValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		cdns          string
+		enablementMap string
+		expectSummary string
+		expectDetail  string
+	}{
+		{
+			name:          "valid",
+			cdns:          testValidationCdns,
+			enablementMap: testValidationEnablementMap,
+		},
+		{
+			name:          "unknown_world_default_id",
+			cdns:          testValidationCdns,
+			enablementMap: `{"world_default": ["cdn1", "cdn_2"], "asn_overrides": {}, "continents": {}}`,
+			expectSummary: "Unknown CDN Identifier",
+			expectDetail:  `Enabled CDN "cdn_2" does not match`,
+		},
+		{
+			name: "unknown_subdivision_asn_override_id",
+			cdns: testValidationCdns,
+			enablementMap: `{"world_default": ["cdn1"], "asn_overrides": {}, "continents": {
+				"NA": {"default": ["cdn1"], "countries": {
+					"US": {"default": ["cdn1"], "asn_overrides": {}, "subdivisions": {"CA": {"asn_overrides": {"12345": ["cdn3"]}}}}
+				}}
+			}}`,
+			expectSummary: "Unknown CDN Identifier",
+			expectDetail:  `Enabled CDN "cdn3" does not match`,
+		},
+		{
+			name:          "empty_continent_default",
+			cdns:          testValidationCdns,
+			enablementMap: `{"world_default": ["cdn1"], "asn_overrides": {}, "continents": {"EU": {"default": []}}}`,
+			expectSummary: "Empty CDN List",
+			expectDetail:  "At least one CDN must be enabled",
+		},
+		{
+			name:          "empty_asn_override",
+			cdns:          testValidationCdns,
+			enablementMap: `{"world_default": ["cdn1"], "asn_overrides": {"12345": []}, "continents": {}}`,
+			expectSummary: "Empty CDN List",
+			expectDetail:  "At least one CDN must be enabled",
+		},
+		{
+			name:          "duplicate_client_cdn_id",
+			cdns:          testValidationCdns + `, {"cdn_name": "cdn1-backup", "fqdn": "backup.example.com", "client_cdn_id": "cdn1"}`,
+			enablementMap: testValidationEnablementMap,
+			expectSummary: "Duplicate Client CDN Identifier",
+			expectDetail:  `client_cdn_id "cdn1" is already used by cdns[0]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateResourceConfig(t, "multicdn_cdn_config", testCdnValidationConfig(tc.cdns, tc.enablementMap, testValidationTrafficOptions))
+			expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tc.expectSummary, tc.expectDetail)
+		})
+	}
+}
+
+// Test plan-time validation of the geographic codes of the CDN enablement map without Terraform
+func TestCdnConfigResource_geoValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		continents    string
+		expectSummary string
+		expectDetail  string
+	}{
+		{
+			name: "valid",
+			continents: `{"NA": {"default": ["cdn1"], "countries": {
+				"US": {"default": ["cdn2"], "asn_overrides": {}, "subdivisions": {"CA": {"asn_overrides": {"12345": ["cdn2"]}}}}
+			}}}`,
+		},
+		{
+			name:          "unknown_continent",
+			continents:    `{"EUR": {"default": ["cdn2"]}}`,
+			expectSummary: "Unknown Continent Code",
+			expectDetail:  `"EUR" is not a continent code`,
+		},
+		{
+			name:          "country_under_wrong_continent",
+			continents:    `{"NA": {"default": ["cdn1"], "countries": {"DE": {"default": ["cdn2"], "asn_overrides": {}}}}}`,
+			expectSummary: "Invalid Country Code",
+			expectDetail:  "DE (Germany) belongs to EU, not NA",
+		},
+		{
+			name: "unknown_subdivision",
+			continents: `{"NA": {"default": ["cdn1"], "countries": {
+				"US": {"default": ["cdn1"], "asn_overrides": {}, "subdivisions": {"ON": {"asn_overrides": {"12345": ["cdn2"]}}}}
+			}}}`,
+			expectSummary: "Unknown Subdivision Code",
+			expectDetail:  `"ON" is not an ISO 3166-2 subdivision code of US`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			enablementMap := fmt.Sprintf(`{"world_default": ["cdn1"], "asn_overrides": {}, "continents": %s}`, tc.continents)
+			diags := validateResourceConfig(t, "multicdn_cdn_config", testCdnValidationConfig(testValidationCdns, enablementMap, testValidationTrafficOptions))
+			expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tc.expectSummary, tc.expectDetail)
+		})
+	}
+}
+
+// Acceptance test for plan-time validation of asn_overrides keys
+func TestAccCdnConfigResource_asnOverrideValidation(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name         string
+		asnOverrides string
+		expectError  *regexp.Regexp
+	}{
+		{
+			name:         "not_a_number",
+			asnOverrides: `"ASN13335" = ["cdn1"]`,
+			expectError:  regexp.MustCompile(`(?s)Invalid ASN.*invalid ASN "ASN13335": expected`),
+		},
+		{
+			name:         "out_of_range",
+			asnOverrides: `"4294967296" = ["cdn1"]`,
+			expectError:  regexp.MustCompile(`(?s)Invalid ASN.*invalid ASN "4294967296": outside`),
+		},
+		{
+			name: "duplicate",
+			asnOverrides: `"AS13335" = ["cdn1"]
+      "13335" = ["cdn2"]`,
+			expectError: regexp.MustCompile(`(?s)Duplicate ASN.*"13335" and "AS13335" both refer`),
+		},
+		{
+			name:         "private_use",
+			asnOverrides: `"AS64512" = ["cdn1"]`,
+			expectError:  regexp.MustCompile(`(?s)Reserved ASN.*AS64512 is reserved for private use`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: factories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccCdnResourceValidationConfig(mockServer.URL, testAccValidationCdns, testAccASNOverridesEnablementMap(tc.asnOverrides), testAccValidationTrafficOptions),
+						PlanOnly:    true,
+						ExpectError: tc.expectError,
+					},
+				},
+			})
+		})
+	}
+}
+
+// Test plan-time validation of asn_overrides keys without Terraform
+func TestCdnConfigResource_asnOverrideValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		asnOverrides  string
+		expectSummary string
+		expectDetail  string
+	}{
+		{
+			name:         "valid",
+			asnOverrides: `{"AS13335": ["cdn1"], "15169": ["cdn2"]}`,
+		},
+		{
+			name:          "not_a_number",
+			asnOverrides:  `{"ASN13335": ["cdn1"]}`,
+			expectSummary: "Invalid ASN",
+			expectDetail:  `invalid ASN "ASN13335": expected`,
+		},
+		{
+			name:          "out_of_range",
+			asnOverrides:  `{"4294967296": ["cdn1"]}`,
+			expectSummary: "Invalid ASN",
+			expectDetail:  `invalid ASN "4294967296": outside`,
+		},
+		{
+			name:          "duplicate",
+			asnOverrides:  `{"AS13335": ["cdn1"], "13335": ["cdn2"]}`,
+			expectSummary: "Duplicate ASN",
+			expectDetail:  `"13335" and "AS13335" both refer`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			enablementMap := fmt.Sprintf(`{"world_default": ["cdn1"], "asn_overrides": %s, "continents": {}}`, tc.asnOverrides)
+			diags := validateResourceConfig(t, "multicdn_cdn_config", testCdnValidationConfig(testValidationCdns, enablementMap, testValidationTrafficOptions))
+			expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tc.expectSummary, tc.expectDetail)
+		})
+	}
+}
+
+// Test that reserved ASNs are rejected while planning unless the provider allows them
+func TestCdnConfigResource_reservedASNs(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name              string
+		allowReservedASNs string
+		expectSummary     string
+		expectDetail      string
+	}{
+		{
+			name:          "default",
+			expectSummary: "Reserved ASN",
+			expectDetail:  "AS64512 is reserved for private use",
+		},
+		{
+			name:              "allowed",
+			allowReservedASNs: `"allow_reserved_asns": true`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := configureTestProvider(t, mockServer.URL, tc.allowReservedASNs)
+
+			config := jsonValue(testCdnValidationConfig(testValidationCdns,
+				`{"world_default": ["cdn1"], "asn_overrides": {"AS64512": ["cdn1"]}, "continents": {}}`, testValidationTrafficOptions))
+			planResp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "multicdn_cdn_config",
+				PriorState:       jsonValue("null"),
+				ProposedNewState: config,
+				Config:           config,
+			})
+			if err != nil {
+				t.Fatalf("PlanResourceChange returned an error: %v", err)
+			}
+
+			expectDiagnostic(t, planResp.Diagnostics, tfprotov6.DiagnosticSeverityError, tc.expectSummary, tc.expectDetail)
+		})
+	}
+}
+
+// Acceptance test for ASN keys sent to the API in canonical form without diffing against the configuration
+func TestAccCdnConfigResource_asnOverrideNormalization(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	config := testAccCdnResourceValidationConfig(mockServer.URL, testAccValidationCdns, testAccASNOverridesEnablementMap(`"as13335" = ["cdn1"]
+      "AS64512" = ["cdn2"]`), testAccValidationTrafficOptions)
+	config = strings.Replace(config, `api_secret = "test-secret"`, `api_secret = "test-secret"
+  allow_reserved_asns = true`, 1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "cdn_enablement_map.asn_overrides.as13335.0", "cdn1"),
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "cdn_enablement_map.asn_overrides.AS64512.0", "cdn2"),
+					func(*terraform.State) error {
+						stored, _ := mockServer.CdnConfig(12345)
+						overrides := stored.CdnEnablementMap.ASNOverrides
+						for _, key := range []string{"13335", "64512"} {
+							if _, ok := overrides[key]; !ok {
+								return fmt.Errorf("expected ASN key %q to be sent to the API, got %v", key, overrides)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// The canonical keys returned by the API must not cause a diff
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// Test that asn_overrides keys written as "ASnnn" are sent to the API as plain numbers and keep their
+// configured spelling through a refresh and plan, both when the API returns the plain numbers and when
+// it returns keys that were written with the prefix by other clients
+func TestCdnConfigResource_asnOverrideRoundTrip(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	for _, apiKey := range []string{"13335", "AS13335"} {
+		t.Run(apiKey, func(t *testing.T) {
+			mockServer.DeleteCdnConfig(12345)
+
+			ctx := context.Background()
+			server := configureTestProvider(t, mockServer.URL, "")
+
+			config := jsonValue(testCdnValidationConfig(testValidationCdns,
+				`{"world_default": ["cdn1"], "asn_overrides": {"AS13335": ["cdn2"]}, "continents": {}}`, testValidationTrafficOptions))
+
+			planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "multicdn_cdn_config",
+				PriorState:       jsonValue("null"),
+				ProposedNewState: config,
+				Config:           config,
+			})
+			if err != nil || len(planResp.Diagnostics) > 0 {
+				t.Fatalf("Failed to plan the CDN configuration: %v %v", err, planResp.Diagnostics)
+			}
+			applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "multicdn_cdn_config",
+				PriorState:   jsonValue("null"),
+				PlannedState: planResp.PlannedState,
+				Config:       config,
+			})
+			if err != nil || len(applyResp.Diagnostics) > 0 {
+				t.Fatalf("Failed to create the CDN configuration: %v %v", err, applyResp.Diagnostics)
+			}
+
+			stored, _ := mockServer.CdnConfig(12345)
+			if _, ok := stored.CdnEnablementMap.ASNOverrides["13335"]; !ok || len(stored.CdnEnablementMap.ASNOverrides) != 1 {
+				t.Fatalf("Expected ASN key %q to be sent to the API in canonical form, got %v", "13335", stored.CdnEnablementMap.ASNOverrides)
+			}
+			stored.CdnEnablementMap.ASNOverrides = map[string][]string{apiKey: {"cdn2"}}
+			mockServer.SetCdnConfig(*stored)
+
+			readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     "multicdn_cdn_config",
+				CurrentState: applyResp.NewState,
+			})
+			if err != nil || len(readResp.Diagnostics) > 0 {
+				t.Fatalf("Failed to read the CDN configuration: %v %v", err, readResp.Diagnostics)
+			}
+
+			state := decodeResourceValue(t, server, "multicdn_cdn_config", applyResp.NewState)
+			if refreshed := decodeResourceValue(t, server, "multicdn_cdn_config", readResp.NewState); !refreshed.Equal(state) {
+				t.Fatalf("Expected the refreshed state to keep the ASN key as written, diff: %v", diffValues(state, refreshed))
+			}
+
+			// Terraform proposes the refreshed state, as it already matches the configuration
+			planResp, err = server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "multicdn_cdn_config",
+				PriorState:       readResp.NewState,
+				ProposedNewState: readResp.NewState,
+				Config:           config,
+			})
+			if err != nil || len(planResp.Diagnostics) > 0 {
+				t.Fatalf("Failed to plan the CDN configuration: %v %v", err, planResp.Diagnostics)
+			}
+			if planned := decodeResourceValue(t, server, "multicdn_cdn_config", planResp.PlannedState); !planned.Equal(state) {
+				t.Errorf("Expected no changes to be planned, diff: %v", diffValues(state, planned))
+			}
+		})
+	}
+}
+
+// Acceptance test for weights that are ignored because traffic is distributed equally
+func TestAccCdnConfigResource_equalWeightIgnoresWeights(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				// Weights only cause a warning, so the configuration applies
+				Config: testAccCdnResourceConfigWithTrafficOptions(mockServer.URL, `
+        {
+          name = "equal"
+          equal_weight = true
+          distribution = [
+            { id = "cdn1", weight = 10 },
+            { id = "cdn2" }
+          ]
+        }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "traffic_distribution.world_default.options.0.equal_weight", "true"),
+				),
+			},
+		},
+	})
+}
+
+// Test that subdivision overrides for countries without enabled subdivisions in the preference configuration
+// of the same resource_id cause a warning while planning
+func TestCdnConfigResource_subdivisionCountryCheck(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	mockServer.SetPreference(preferenceclient.Preference{
+		ResourceID: 12345,
+		EnabledSubdivisionCountries: preferenceclient.EnabledSubdivisionCountries{
+			Continents: map[string]preferenceclient.ContinentSubdivisions{
+				"NA": {Countries: []string{"CA"}},
+			},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		resourceID      int64
+		expectWarnings  []string
+		expectCountries []string
+	}{
+		{
+			name:            "country_not_enabled",
+			resourceID:      12345,
+			expectWarnings:  []string{"does not list it in enabled_subdivision_countries"},
+			expectCountries: []string{"US"},
+		},
+		{
+			name:            "no_preference",
+			resourceID:      67890,
+			expectWarnings:  []string{"no preference configuration with resource_id 67890 exists", "no preference configuration with resource_id 67890 exists"},
+			expectCountries: []string{"CA", "US"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := configureTestProvider(t, mockServer.URL, "")
+
+			config := jsonValue(fmt.Sprintf(`{
+				"resource_id": %d,
+				"cdns": [{"cdn_name": "cdn1", "fqdn": "cdn1.example.com", "client_cdn_id": "cdn1"}],
+				"cdn_enablement_map": {
+					"world_default": ["cdn1"],
+					"asn_overrides": {},
+					"continents": {
+						"NA": {
+							"default": ["cdn1"],
+							"countries": {
+								"US": {"default": ["cdn1"], "asn_overrides": {}, "subdivisions": {"CA": {"asn_overrides": {"13335": ["cdn1"]}}}},
+								"CA": {"default": ["cdn1"], "asn_overrides": {}, "subdivisions": {"ON": {"asn_overrides": {"13335": ["cdn1"]}}}},
+								"MX": {"default": ["cdn1"], "asn_overrides": {}, "subdivisions": {}}
+							}
+						}
+					}
+				}
+			}`, tc.resourceID))
+
+			planResp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "multicdn_cdn_config",
+				PriorState:       jsonValue("null"),
+				ProposedNewState: config,
+				Config:           config,
+			})
+			if err != nil {
+				t.Fatalf("PlanResourceChange returned an error: %v", err)
+			}
+
+			var warnings, countries []string
+			for _, d := range planResp.Diagnostics {
+				if d.Severity != tfprotov6.DiagnosticSeverityWarning || d.Summary != "Subdivision Overrides Not Enabled" {
+					t.Errorf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+					continue
+				}
+				warnings = append(warnings, d.Detail)
+				countries = append(countries, d.Attribute.String())
+			}
+
+			if len(warnings) != len(tc.expectWarnings) {
+				t.Fatalf("Expected %d warnings, got %d: %v", len(tc.expectWarnings), len(warnings), warnings)
+			}
+			for i, expected := range tc.expectWarnings {
+				if !strings.Contains(warnings[i], expected) {
+					t.Errorf("Expected warning %d to contain %q, got %q", i, expected, warnings[i])
+				}
+				if !strings.Contains(countries[i], fmt.Sprintf("ElementKeyString(%q).AttributeName(\"subdivisions\")", tc.expectCountries[i])) {
+					t.Errorf("Expected warning %d for country %s, got %s", i, tc.expectCountries[i], countries[i])
+				}
+			}
+		})
+	}
+}
+
+// Default CDN entries of the JSON validation test configurations
+const testValidationCdns = `{"cdn_name": "cdn1", "fqdn": "cdn1.example.com", "client_cdn_id": "cdn1"},
+	{"cdn_name": "cdn2", "fqdn": "cdn2.example.com", "client_cdn_id": "cdn2"}`
+
+// Default enablement map of the JSON validation test configurations
+const testValidationEnablementMap = `{"world_default": ["cdn1", "cdn2"], "asn_overrides": {}, "continents": {}}`
+
+// Default traffic options of the JSON validation test configurations
+const testValidationTrafficOptions = `{"name": "equal", "equal_weight": true, "distribution": [{"id": "cdn1"}, {"id": "cdn2"}]}`
+
+// Helper function returning a CDN configuration in JSON with the given CDN entries, enablement map and world default traffic options
+func testCdnValidationConfig(cdns, enablementMap, options string) string {
+	return fmt.Sprintf(`{
+		"resource_id": 12345,
+		"cdns": [%s],
+		"cdn_enablement_map": %s,
+		"traffic_distribution": {"world_default": {"options": [%s]}}
+	}`, cdns, enablementMap, options)
+}
+
+// Default CDN entries of the validation test configurations
+const testAccValidationCdns = `
+    {
+      cdn_name = "cdn1"
+      fqdn = "cdn1.example.com"
+      client_cdn_id = "cdn1"
+    },
+    {
+      cdn_name = "cdn2"
+      fqdn = "cdn2.example.com"
+      client_cdn_id = "cdn2"
+    }`
+
+// Default traffic options of the validation test configurations
+const testAccValidationTrafficOptions = `
+        {
+          name = "default"
+          equal_weight = true
+          distribution = [
+            { id = "cdn1" },
+            { id = "cdn2" }
+          ]
+        }`
+
+// Helper function returning an enablement map body with the given world asn_overrides entries
+func testAccASNOverridesEnablementMap(asnOverrides string) string {
+	return fmt.Sprintf(`world_default = ["cdn1", "cdn2"]
+    asn_overrides = {
+      %s
+    }`, asnOverrides)
+}
+
+// Helper function returning a CDN configuration with the given world default traffic options
+func testAccCdnResourceConfigWithTrafficOptions(serverURL, options string) string {
+	return testAccCdnResourceValidationConfig(serverURL, testAccValidationCdns, `world_default = ["cdn1", "cdn2"]`, options)
+}
+
+// Helper function returning a CDN configuration with the given CDN entries, enablement map body and world default traffic options
+func testAccCdnResourceValidationConfig(serverURL, cdns, enablementMap, options string) string {
+	return fmt.Sprintf(`
+provider "multicdn" {
+  api_key = "test-key"
+  api_secret = "test-secret"
+  base_url = "%s"
+}
+
+resource "multicdn_cdn_config" "test" {
+  resource_id = 12345
+  content_type = "application/json"
+
+  cdns = [%s
+  ]
+
+  cdn_enablement_map = {
+    %s
+  }
+
+  traffic_distribution = {
+    world_default = {
+      options = [%s
+      ]
+    }
+  }
+}
+`, serverURL, cdns, enablementMap, options)
+}
@@ -6,17 +6,32 @@ import (
 	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
 )
 
-// APIClient wraps the preference client
+// APIClient wraps the preference client, together with the provider settings resources depend on
 type APIClient struct {
 	preference *preferenceclient.Client
 	cdn        *cdnclient.Client
+
+	// allowReservedASNs permits reserved and private-use ASNs in asn_overrides
+	allowReservedASNs bool
+
+	// optimisticConcurrency makes updates conditional on the version last read from the API
+	optimisticConcurrency bool
+
+	// adoptExisting makes creates overwrite and manage configurations that already exist instead of failing
+	adoptExisting bool
+
+	// deletionProtection is the deletion protection of resources that do not configure it
+	deletionProtection bool
 }
 
-// NewAPIClient creates a new API client for the provider
-func NewAPIClient(baseURL, apiKey, apiSecret string) *APIClient {
-	httpClient := httpclient.New(baseURL, apiKey, apiSecret)
+// NewAPIClient creates a new API client for the provider.
+// The options are passed through to the underlying HTTP client shared by all API clients.
+func NewAPIClient(baseURL, apiKey, apiSecret string, options ...httpclient.ClientOption) *APIClient {
+	httpClient := httpclient.New(baseURL, apiKey, apiSecret, options...)
 	return &APIClient{
 		preference: preferenceclient.New(httpClient),
 		cdn:        cdnclient.New(httpClient),
+
+		optimisticConcurrency: true,
 	}
 }
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
+)
+
+// expectedVersion returns the version an update must still find on the API, taken from the prior state.
+// It is empty when optimistic concurrency is disabled or the API did not report a version, in which
+// case the update overwrites the configuration unconditionally.
+//
+// A version set in the configuration is sent with every update and kept by the API, so it stays the
+// same across changes and the check cannot tell them apart. The documentation of optimistic_concurrency
+// and of the version attributes says so.
+func (c *APIClient) expectedVersion(ctx context.Context, state tfsdk.State, diags *diag.Diagnostics) string {
+	if !c.optimisticConcurrency {
+		return ""
+	}
+
+	var version types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("version"), &version)...)
+	return version.ValueString()
+}
+
+// updateOptions returns the request options making an update conditional on the expected version
+func updateOptions(expectedVersion string) []httpclient.RequestOption {
+	if expectedVersion == "" {
+		return nil
+	}
+	return []httpclient.RequestOption{httpclient.WithIfMatch(expectedVersion)}
+}
+
+// isConcurrentModification reports whether a conditional update was rejected because the
+// configuration no longer has the expected version. Only 412 Precondition Failed answers the
+// If-Match header; other errors, such as 409 Conflict, are reported as they are.
+func isConcurrentModification(err error, expectedVersion string) bool {
+	return expectedVersion != "" && response.IsPreconditionFailed(err)
+}
+
+// addConcurrentModificationError reports an update rejected because someone else changed the configuration
+func addConcurrentModificationError(diags *diag.Diagnostics, kind string, resourceID int64, expectedVersion string) {
+	diags.AddError(
+		"Configuration Modified Outside Terraform",
+		fmt.Sprintf("The %s ID %d was modified outside this Terraform run: it no longer has version %q, "+
+			"which the plan was based on, so it was left unchanged to avoid overwriting those changes. "+
+			"Run terraform again to refresh the state and review a new plan, or set optimistic_concurrency = false "+
+			"in the provider configuration to always overwrite the configuration.", kind, resourceID, expectedVersion),
+	)
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttributeDescription describes the deletion_protection attribute of a resource
+func deletionProtectionAttributeDescription(kind string) string {
+	return fmt.Sprintf("Whether deleting the %s fails, including when it is destroyed or replaced. "+
+		"Set to false and apply before deleting it. Defaults to the deletion_protection setting of the provider", kind)
+}
+
+// planDeletionProtection plans the provider's default deletion protection for resources that do not configure it.
+// It is left unknown while the provider is not configured yet.
+func planDeletionProtection(ctx context.Context, client *APIClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &configured)...)
+	if configured.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), client.deletionProtection)...)
+	}
+}
+
+// deletionProtectionOrDefault returns the deletion protection of a resource, or the provider default if it is not known,
+// e.g. in the state of resources imported or created by an earlier provider version
+func (c *APIClient) deletionProtectionOrDefault(value types.Bool) types.Bool {
+	if value.IsNull() || value.IsUnknown() {
+		return types.BoolValue(c.deletionProtection)
+	}
+	return value
+}
+
+// addDeletionProtectionError reports a delete refused because the resource is protected
+func addDeletionProtectionError(diags *diag.Diagnostics, kind string, resourceID int64) {
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s ID %d has deletion_protection enabled, so it was not deleted. "+
+			"To delete it, set deletion_protection = false and apply, then destroy it or remove it from the configuration.", kind, resourceID),
+	)
+}
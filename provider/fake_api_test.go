@@ -0,0 +1,23 @@
+package provider_test
+
+import (
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/multicdntest"
+	"github.com/constellix/terraform-provider-constellix-multicdn/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// setupAccProtoV6ProviderFactories starts a fake MultiCDN API and creates provider factories for tests against it.
+// The fake accepts the credentials "test-key" and "test-secret".
+func setupAccProtoV6ProviderFactories() (*multicdntest.Server, map[string]func() (tfprotov6.ProviderServer, error)) {
+	mockServer := multicdntest.NewServer()
+
+	factories := map[string]func() (tfprotov6.ProviderServer, error){
+		"multicdn": func() (tfprotov6.ProviderServer, error) {
+			testProvider := provider.New()
+			return providerserver.NewProtocol6(testProvider)(), nil
+		},
+	}
+
+	return mockServer, factories
+}
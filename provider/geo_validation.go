@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/internal/geo"
+)
+
+// validateContinentCode reports a diagnostic at attributePath when code is not a continent code
+func validateContinentCode(code string, attributePath path.Path, diags *diag.Diagnostics) {
+	if err := geo.ValidateContinent(code); err != nil {
+		diags.AddAttributeError(attributePath, "Unknown Continent Code", err.Error()+".")
+	}
+}
+
+// validateCountryCode reports a diagnostic at attributePath when code is not an ISO 3166-1 alpha-2
+// country code or the country does not belong to the continent
+func validateCountryCode(continent, code string, attributePath path.Path, diags *diag.Diagnostics) {
+	if err := geo.ValidateCountry(continent, code); err != nil {
+		diags.AddAttributeError(attributePath, "Invalid Country Code", err.Error()+".")
+	}
+}
+
+// validateSubdivisionCode reports a diagnostic at attributePath when code is not an ISO 3166-2
+// subdivision of the country
+func validateSubdivisionCode(country, code string, attributePath path.Path, diags *diag.Diagnostics) {
+	if err := geo.ValidateSubdivision(country, code); err != nil {
+		diags.AddAttributeError(attributePath, "Unknown Subdivision Code", err.Error()+".")
+	}
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// markAPIManagedAttributesUnknown marks last_updated, and version unless it is configured, as unknown when
+// a resource is updated. UseStateForUnknown keeps their prior values in plans without changes, but the API
+// sets new values on every update, which would otherwise not match the plan. The plan is compared as modified
+// so far, so defaults planned from provider settings are taken into account.
+func markAPIManagedAttributesUnknown(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Creates have no prior values, destroys have no plan, and plans without changes keep the prior values
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), types.StringUnknown())...)
+
+	var version types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("version"), &version)...)
+	if version.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), types.StringUnknown())...)
+	}
+}
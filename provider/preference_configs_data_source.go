@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+)
+
+// Ensure data source implements required interfaces
+var (
+	_ datasource.DataSource              = &preferenceConfigsDataSource{}
+	_ datasource.DataSourceWithConfigure = &preferenceConfigsDataSource{}
+)
+
+// preferenceConfigsDataSource is the data source implementation
+type preferenceConfigsDataSource struct {
+	client *APIClient
+}
+
+// preferenceConfigsDataSourceModel maps the data source schema
+type preferenceConfigsDataSourceModel struct {
+	ContentType        types.String                `tfsdk:"content_type"`
+	OverridesContinent types.String                `tfsdk:"overrides_continent"`
+	Configs            []preferenceDataSourceModel `tfsdk:"configs"`
+}
+
+// NewPreferenceConfigsDataSource creates a new data source listing preference configurations
+func NewPreferenceConfigsDataSource() datasource.DataSource {
+	return &preferenceConfigsDataSource{}
+}
+
+// Metadata returns the data source metadata
+func (d *preferenceConfigsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "multicdn_preference_configs"
+}
+
+// Schema defines the schema for the data source
+func (d *preferenceConfigsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all CDN preference configurations of the account, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			"content_type": schema.StringAttribute{
+				Description: "Only return configurations with exactly this content type",
+				Optional:    true,
+			},
+			"overrides_continent": schema.StringAttribute{
+				Description: "Only return configurations with continent-specific availability thresholds, " +
+					"performance filtering or enabled subdivision countries for this continent code (e.g. EU)",
+				Optional: true,
+			},
+			"configs": schema.ListNestedAttribute{
+				Description: "Preference configurations matching all given filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: preferenceConfigDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the data source with the provider client
+func (d *preferenceConfigsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *APIClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read lists the preference configurations from the API
+func (d *preferenceConfigsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Read the configuration
+	var state preferenceConfigsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Walk all pages of preference configurations
+	preferences, err := d.client.preference.GetAllPreferences(ctx, preferenceclient.DefaultPageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Preferences",
+			fmt.Sprintf("Unable to list preference configurations: %s", err),
+		)
+		return
+	}
+
+	// Keep only the configurations matching every filter
+	state.Configs = make([]preferenceDataSourceModel, 0, len(preferences))
+	for i := range preferences {
+		if state.matches(&preferences[i]) {
+			state.Configs = append(state.Configs, preferenceDataSourceModelFromAPI(&preferences[i]))
+		}
+	}
+
+	// Save the data into Terraform state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// matches reports whether the API preference satisfies all filters set on the model
+func (m *preferenceConfigsDataSourceModel) matches(preference *preferenceclient.Preference) bool {
+	if !m.ContentType.IsNull() && preference.ContentType != m.ContentType.ValueString() {
+		return false
+	}
+
+	if !m.OverridesContinent.IsNull() && !overridesContinent(preference, m.OverridesContinent.ValueString()) {
+		return false
+	}
+
+	return true
+}
+
+// overridesContinent reports whether any section of the preference has a continent-specific entry
+func overridesContinent(preference *preferenceclient.Preference, continent string) bool {
+	if _, ok := preference.AvailabilityThresholds.Continents[continent]; ok {
+		return true
+	}
+	if _, ok := preference.PerformanceFiltering.Continents[continent]; ok {
+		return true
+	}
+	if _, ok := preference.EnabledSubdivisionCountries.Continents[continent]; ok {
+		return true
+	}
+	return false
+}
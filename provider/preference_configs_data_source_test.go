@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance test listing preference configurations spread over several pages
+func TestAccPreferenceConfigsDataSource_pagination(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	// Seed enough preferences to span three pages of the default page size,
+	// every third of them overriding Asia
+	for i := int64(1); i <= 2*preferenceclient.DefaultPageSize+10; i++ {
+		preference := preferenceclient.Preference{
+			ResourceID:  i,
+			ContentType: "text/html",
+			Description: "Seeded preference",
+			Version:     "1",
+		}
+		if i%3 == 0 {
+			preference.AvailabilityThresholds.Continents = map[string]preferenceclient.ContinentThreshold{
+				"AS": {Default: 90},
+			}
+		}
+		mockServer.SetPreference(preference)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPreferenceResourceConfigComprehensive(mockServer.URL, "Listed preference") + `
+data "multicdn_preference_configs" "all" {
+  depends_on = [multicdn_preference_config.comprehensive]
+}
+
+data "multicdn_preference_configs" "json" {
+  content_type = "application/json"
+
+  depends_on = [multicdn_preference_config.comprehensive]
+}
+
+data "multicdn_preference_configs" "asia" {
+  content_type        = "text/html"
+  overrides_continent = "AS"
+
+  depends_on = [multicdn_preference_config.comprehensive]
+}
+
+data "multicdn_preference_configs" "europe" {
+  overrides_continent = "EU"
+
+  depends_on = [multicdn_preference_config.comprehensive]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.all", "configs.#", "111"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.all", "configs.0.resource_id", "1"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.all", "configs.109.resource_id", "110"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.json", "configs.#", "1"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.json", "configs.0.resource_id", "54321"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.json", "configs.0.description", "Listed preference"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.asia", "configs.#", "36"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.asia", "configs.0.availability_thresholds.continents.AS.default", "90"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.europe", "configs.#", "1"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_configs.europe", "configs.0.resource_id", "54321"),
+				),
+			},
+		},
+	})
+}
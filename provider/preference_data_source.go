@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+)
+
+// Ensure data source implements required interfaces
+var (
+	_ datasource.DataSource              = &preferenceDataSource{}
+	_ datasource.DataSourceWithConfigure = &preferenceDataSource{}
+)
+
+// preferenceDataSource is the data source implementation
+type preferenceDataSource struct {
+	client *APIClient
+}
+
+// preferenceDataSourceModel maps the data source schema to the API client model
+type preferenceDataSourceModel struct {
+	ResourceID                  types.Int64                       `tfsdk:"resource_id"`
+	ContentType                 types.String                      `tfsdk:"content_type"`
+	Description                 types.String                      `tfsdk:"description"`
+	Version                     types.String                      `tfsdk:"version"`
+	LastUpdated                 types.String                      `tfsdk:"last_updated"`
+	AvailabilityThresholds      *availabilityThresholdsModel      `tfsdk:"availability_thresholds"`
+	PerformanceFiltering        *performanceFilteringModel        `tfsdk:"performance_filtering"`
+	EnabledSubdivisionCountries *enabledSubdivisionCountriesModel `tfsdk:"enabled_subdivision_countries"`
+}
+
+// NewPreferenceDataSource creates a new preference configuration data source
+func NewPreferenceDataSource() datasource.DataSource {
+	return &preferenceDataSource{}
+}
+
+// Metadata returns the data source metadata
+func (d *preferenceDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "multicdn_preference_config"
+}
+
+// Schema defines the schema for the data source
+func (d *preferenceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := preferenceConfigDataSourceAttributes()
+	attributes["resource_id"] = schema.Int64Attribute{
+		Description: "Unique ID of the CDN preference configuration to read",
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Reads an existing CDN preference configuration",
+		Attributes:  attributes,
+	}
+}
+
+// Configure configures the data source with the provider client
+func (d *preferenceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *APIClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read reads the preference configuration from the API
+func (d *preferenceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Read the configuration
+	var config preferenceDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the resource ID from configuration
+	resourceID := config.ResourceID.ValueInt64()
+
+	// Call the API client to get the preference
+	preference, err := d.client.preference.GetPreference(ctx, resourceID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Preference",
+			fmt.Sprintf("Unable to read preference ID %d: %s", resourceID, err),
+		)
+		return
+	}
+
+	// Convert API model to Terraform model
+	state := preferenceDataSourceModelFromAPI(preference)
+
+	// Save the data into Terraform state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// preferenceDataSourceModelFromAPI converts an API preference into the data source model,
+// reusing the resource conversion so both expose the same nested shape
+func preferenceDataSourceModelFromAPI(preference *preferenceclient.Preference) preferenceDataSourceModel {
+	var converter preferenceResource
+	var model preferenceResourceModel
+	converter.convertFromAPIModel(preference, &model)
+
+	return preferenceDataSourceModel{
+		ResourceID:                  model.ResourceID,
+		ContentType:                 model.ContentType,
+		Description:                 model.Description,
+		Version:                     model.Version,
+		LastUpdated:                 model.LastUpdated,
+		AvailabilityThresholds:      model.AvailabilityThresholds,
+		PerformanceFiltering:        model.PerformanceFiltering,
+		EnabledSubdivisionCountries: model.EnabledSubdivisionCountries,
+	}
+}
+
+// preferenceConfigDataSourceAttributes returns the computed attributes describing a preference configuration.
+// The nested shape mirrors the multicdn_preference_config resource schema.
+func preferenceConfigDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"resource_id": schema.Int64Attribute{
+			Description: "Unique ID of the CDN preference configuration",
+			Computed:    true,
+		},
+		"content_type": schema.StringAttribute{
+			Description: "Content type of the CDN preference configuration",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description of the CDN preference configuration",
+			Computed:    true,
+		},
+		"version": schema.StringAttribute{
+			Description: "Version of the CDN preference configuration",
+			Computed:    true,
+		},
+		"last_updated": schema.StringAttribute{
+			Description: "Timestamp of when the configuration was last updated",
+			Computed:    true,
+		},
+		"availability_thresholds": schema.SingleNestedAttribute{
+			Description: "Availability thresholds configuration",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"world": schema.Float64Attribute{
+					Description: "Global availability threshold in percent (0-100), e.g. 99.5",
+					Computed:    true,
+				},
+				"continents": schema.MapNestedAttribute{
+					Description: "Continent-specific availability thresholds",
+					Computed:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"default": schema.Float64Attribute{
+								Description: "Default threshold for the continent (0-100)",
+								Computed:    true,
+							},
+							"countries": schema.MapAttribute{
+								Description: "Country-specific thresholds (0-100)",
+								Computed:    true,
+								ElementType: types.Float64Type,
+							},
+						},
+					},
+				},
+			},
+		},
+		"performance_filtering": schema.SingleNestedAttribute{
+			Description: "Performance filtering configuration",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"world": schema.SingleNestedAttribute{
+					Description: "Global performance filtering configuration",
+					Computed:    true,
+					Attributes: map[string]schema.Attribute{
+						"mode": schema.StringAttribute{
+							Description: "Performance filtering mode (relative or absolute)",
+							Computed:    true,
+						},
+						"relative_threshold": schema.Float64Attribute{
+							Description: "Relative performance threshold",
+							Computed:    true,
+						},
+					},
+				},
+				"continents": schema.MapNestedAttribute{
+					Description: "Continent-specific performance configurations",
+					Computed:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"mode": schema.StringAttribute{
+								Description: "Performance filtering mode for the continent",
+								Computed:    true,
+							},
+							"relative_threshold": schema.Float64Attribute{
+								Description: "Relative performance threshold for the continent",
+								Computed:    true,
+							},
+							"countries": schema.MapNestedAttribute{
+								Description: "Country-specific performance configurations",
+								Computed:    true,
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"mode": schema.StringAttribute{
+											Description: "Performance filtering mode for the country",
+											Computed:    true,
+										},
+										"relative_threshold": schema.Float64Attribute{
+											Description: "Relative performance threshold for the country",
+											Computed:    true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"enabled_subdivision_countries": schema.SingleNestedAttribute{
+			Description: "Configuration for countries with enabled subdivisions",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"continents": schema.MapNestedAttribute{
+					Description: "Continent-specific subdivision configurations",
+					Computed:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"countries": schema.ListAttribute{
+								Description: "List of countries with enabled subdivisions",
+								Computed:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance test reading a preference configuration through the data source
+func TestAccPreferenceConfigDataSource_basic(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPreferenceResourceConfigComprehensive(mockServer.URL, "Owned by another team") + `
+data "multicdn_preference_config" "test" {
+  resource_id = multicdn_preference_config.comprehensive.resource_id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.multicdn_preference_config.test", "resource_id", "54321"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_config.test", "description", "Owned by another team"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_config.test", "availability_thresholds.world", "95"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_config.test", "availability_thresholds.continents.NA.countries.US", "99"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_config.test", "performance_filtering.world.mode", "relative"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_config.test", "performance_filtering.continents.EU.mode", "absolute"),
+					resource.TestCheckResourceAttr("data.multicdn_preference_config.test", "enabled_subdivision_countries.continents.NA.countries.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// Acceptance test for reading a preference configuration that does not exist
+func TestAccPreferenceConfigDataSource_notFound(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "multicdn" {
+  api_key    = "test-key"
+  api_secret = "test-secret"
+  base_url   = "%s"
+}
+
+data "multicdn_preference_config" "missing" {
+  resource_id = 404
+}
+`, mockServer.URL),
+				ExpectError: regexp.MustCompile(`Unable to read preference ID 404`),
+			},
+		},
+	})
+}
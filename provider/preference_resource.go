@@ -6,20 +6,38 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/response"
 	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
 )
 
 // Ensure resource implements required interfaces
 var (
-	_ resource.Resource                = &preferenceResource{}
-	_ resource.ResourceWithImportState = &preferenceResource{}
+	_ resource.Resource                   = &preferenceResource{}
+	_ resource.ResourceWithImportState    = &preferenceResource{}
+	_ resource.ResourceWithValidateConfig = &preferenceResource{}
+	_ resource.ResourceWithUpgradeState   = &preferenceResource{}
+	_ resource.ResourceWithModifyPlan     = &preferenceResource{}
+)
+
+// Performance filtering modes accepted by the API
+const (
+	performanceModeRelative = "relative"
+	performanceModeAbsolute = "absolute"
 )
 
 // preferenceResource is the resource implementation
@@ -34,6 +52,8 @@ type preferenceResourceModel struct {
 	Description                 types.String                      `tfsdk:"description"`
 	Version                     types.String                      `tfsdk:"version"`
 	LastUpdated                 types.String                      `tfsdk:"last_updated"`
+	Timeouts                    timeouts.Value                    `tfsdk:"timeouts"`
+	DeletionProtection          types.Bool                        `tfsdk:"deletion_protection"`
 	AvailabilityThresholds      *availabilityThresholdsModel      `tfsdk:"availability_thresholds"`
 	PerformanceFiltering        *performanceFilteringModel        `tfsdk:"performance_filtering"`
 	EnabledSubdivisionCountries *enabledSubdivisionCountriesModel `tfsdk:"enabled_subdivision_countries"`
@@ -41,14 +61,14 @@ type preferenceResourceModel struct {
 
 // availabilityThresholdsModel maps the AvailabilityThresholds schema
 type availabilityThresholdsModel struct {
-	World      types.Int64                         `tfsdk:"world"`
+	World      types.Float64                       `tfsdk:"world"`
 	Continents map[string]*continentThresholdModel `tfsdk:"continents"`
 }
 
 // continentThresholdModel maps the ContinentThreshold schema
 type continentThresholdModel struct {
-	Default   types.Int64            `tfsdk:"default"`
-	Countries map[string]types.Int64 `tfsdk:"countries"`
+	Default   types.Float64            `tfsdk:"default"`
+	Countries map[string]types.Float64 `tfsdk:"countries"`
 }
 
 // performanceFilteringModel maps the PerformanceFiltering schema
@@ -91,9 +111,11 @@ func (r *preferenceResource) Metadata(_ context.Context, _ resource.MetadataRequ
 }
 
 // Schema defines the schema for the resource
-func (r *preferenceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *preferenceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a CDN preference configuration",
+		// Version 1 changed availability thresholds from whole numbers to decimals
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"resource_id": schema.Int64Attribute{
 				Description: "Unique ID of the CDN preference configuration",
@@ -111,34 +133,56 @@ func (r *preferenceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 			},
 			"version": schema.StringAttribute{
-				Description: "Version of the CDN preference configuration",
+				Description: "Version of the CDN preference configuration. Set by the API unless configured. A configured version is kept on every update, so optimistic concurrency cannot detect changes made outside Terraform",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
-				Description: "Timestamp of when the configuration was last updated",
+				Description: "Timestamp of when the configuration was last updated, set by the API",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeoutsAttribute(ctx),
+			"deletion_protection": schema.BoolAttribute{
+				Description: deletionProtectionAttributeDescription("preference"),
 				Optional:    true,
+				Computed:    true,
 			},
 			"availability_thresholds": schema.SingleNestedAttribute{
 				Description: "Availability thresholds configuration",
 				Required:    true,
 				Attributes: map[string]schema.Attribute{
-					"world": schema.Int64Attribute{
-						Description: "Global availability threshold (0-100)",
+					"world": schema.Float64Attribute{
+						Description: "Global availability threshold in percent (0-100), e.g. 99.5",
 						Optional:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 100),
+						},
 					},
 					"continents": schema.MapNestedAttribute{
 						Description: "Continent-specific availability thresholds",
 						Required:    true,
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
-								"default": schema.Int64Attribute{
+								"default": schema.Float64Attribute{
 									Description: "Default threshold for the continent (0-100)",
 									Required:    true,
+									Validators: []validator.Float64{
+										float64validator.Between(0, 100),
+									},
 								},
 								"countries": schema.MapAttribute{
 									Description: "Country-specific thresholds (0-100)",
 									Required:    true,
-									ElementType: types.Int64Type,
+									ElementType: types.Float64Type,
+									Validators: []validator.Map{
+										mapvalidator.ValueFloat64sAre(float64validator.Between(0, 100)),
+									},
 								},
 							},
 						},
@@ -156,10 +200,16 @@ func (r *preferenceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 							"mode": schema.StringAttribute{
 								Description: "Performance filtering mode (relative or absolute)",
 								Required:    true,
+								Validators: []validator.String{
+									stringvalidator.OneOf(performanceModeRelative, performanceModeAbsolute),
+								},
 							},
 							"relative_threshold": schema.Float64Attribute{
-								Description: "Relative performance threshold",
+								Description: "Relative performance threshold (0.0-1.0), required when mode is relative",
 								Optional:    true,
+								Validators: []validator.Float64{
+									float64validator.Between(0, 1),
+								},
 							},
 						},
 					},
@@ -171,10 +221,16 @@ func (r *preferenceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 								"mode": schema.StringAttribute{
 									Description: "Performance filtering mode for the continent",
 									Required:    true,
+									Validators: []validator.String{
+										stringvalidator.OneOf(performanceModeRelative, performanceModeAbsolute),
+									},
 								},
 								"relative_threshold": schema.Float64Attribute{
-									Description: "Relative performance threshold for the continent",
+									Description: "Relative performance threshold for the continent (0.0-1.0), required when mode is relative",
 									Optional:    true,
+									Validators: []validator.Float64{
+										float64validator.Between(0, 1),
+									},
 								},
 								"countries": schema.MapNestedAttribute{
 									Description: "Country-specific performance configurations",
@@ -184,10 +240,16 @@ func (r *preferenceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 											"mode": schema.StringAttribute{
 												Description: "Performance filtering mode for the country",
 												Required:    true,
+												Validators: []validator.String{
+													stringvalidator.OneOf(performanceModeRelative, performanceModeAbsolute),
+												},
 											},
 											"relative_threshold": schema.Float64Attribute{
-												Description: "Relative performance threshold for the country",
+												Description: "Relative performance threshold for the country (0.0-1.0), required when mode is relative",
 												Optional:    true,
+												Validators: []validator.Float64{
+													float64validator.Between(0, 1),
+												},
 											},
 										},
 									},
@@ -220,6 +282,13 @@ func (r *preferenceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	}
 }
 
+// ModifyPlan plans the default deletion protection and marks the attributes set by the API as unknown
+// when the preference configuration is updated
+func (r *preferenceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, r.client, req, resp)
+	markAPIManagedAttributesUnknown(ctx, req, resp)
+}
+
 // Configure configures the resource with the provider client
 func (r *preferenceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -248,12 +317,31 @@ func (r *preferenceResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Convert Terraform model to API model
 	apiPreference := r.convertToAPIModel(&plan)
 
 	// Call the API client to create the preference
 	err := r.client.preference.CreatePreference(ctx, apiPreference)
-	if err != nil {
+	if response.IsConflict(err) {
+		if !r.client.adoptExisting {
+			addAlreadyExistsError(&resp.Diagnostics, "preference", apiPreference.ResourceID)
+			return
+		}
+
+		r.adoptExisting(ctx, apiPreference, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Preference",
 			fmt.Sprintf("Unable to create preference, got error: %s", err),
@@ -273,12 +361,38 @@ func (r *preferenceResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Convert API model back to Terraform model
 	r.convertFromAPIModel(apiPreference, &plan)
+	plan.DeletionProtection = r.client.deletionProtectionOrDefault(plan.DeletionProtection)
 
 	// Save the data into Terraform state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// adoptExisting overwrites a preference that already exists with the planned one
+func (r *preferenceResource) adoptExisting(ctx context.Context, apiPreference *preferenceclient.Preference, diags *diag.Diagnostics) {
+	resourceID := apiPreference.ResourceID
+
+	existing, err := r.client.preference.GetPreference(ctx, resourceID)
+	if err != nil {
+		diags.AddError(
+			"Error Adopting Preference",
+			fmt.Sprintf("Unable to read existing preference ID %d: %s", resourceID, err),
+		)
+		return
+	}
+
+	expectedVersion := r.client.adoptVersion(existing.Version)
+	if err := r.client.preference.UpdatePreference(ctx, resourceID, apiPreference, updateOptions(expectedVersion)...); err != nil {
+		diags.AddError(
+			"Error Adopting Preference",
+			fmt.Sprintf("Unable to overwrite existing preference ID %d: %s", resourceID, err),
+		)
+		return
+	}
+
+	addAdoptedWarning(diags, "preference", resourceID, existing.Version)
+}
+
 // Read reads the preference configuration from the API
 func (r *preferenceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Read the current state
@@ -289,11 +403,28 @@ func (r *preferenceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get the resource ID from state
 	resourceID := state.ResourceID.ValueInt64()
 
 	// Call the API client to get the preference
 	preference, err := r.client.preference.GetPreference(ctx, resourceID)
+	if response.IsNotFound(err) {
+		// The preference was deleted outside of Terraform, so plan to re-create it
+		tflog.Warn(ctx, "Preference not found, removing from state", map[string]interface{}{
+			"resource_id": resourceID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Preference",
@@ -304,6 +435,7 @@ func (r *preferenceResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// Convert API model to Terraform model
 	r.convertFromAPIModel(preference, &state)
+	state.DeletionProtection = r.client.deletionProtectionOrDefault(state.DeletionProtection)
 
 	// Save the updated data into Terraform state
 	diags = resp.State.Set(ctx, state)
@@ -320,14 +452,33 @@ func (r *preferenceResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Get the resource ID from plan
 	resourceID := plan.ResourceID.ValueInt64()
 
+	// Only overwrite the version the plan was based on
+	expectedVersion := r.client.expectedVersion(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert Terraform model to API model
 	apiPreference := r.convertToAPIModel(&plan)
 
 	// Call the API client to update the preference
-	err := r.client.preference.UpdatePreference(ctx, resourceID, apiPreference)
+	err := r.client.preference.UpdatePreference(ctx, resourceID, apiPreference, updateOptions(expectedVersion)...)
+	if isConcurrentModification(err, expectedVersion) {
+		addConcurrentModificationError(&resp.Diagnostics, "preference", resourceID, expectedVersion)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Preference",
@@ -348,6 +499,7 @@ func (r *preferenceResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Convert API model back to Terraform model
 	r.convertFromAPIModel(apiPreference, &plan)
+	plan.DeletionProtection = r.client.deletionProtectionOrDefault(plan.DeletionProtection)
 
 	// Save the updated data into Terraform state
 	diags = resp.State.Set(ctx, plan)
@@ -364,11 +516,33 @@ func (r *preferenceResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Get the resource ID from state
 	resourceID := state.ResourceID.ValueInt64()
 
+	// Protected resources must have deletion_protection set to false in a prior apply
+	if r.client.deletionProtectionOrDefault(state.DeletionProtection).ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "preference", resourceID)
+		return
+	}
+
 	// Call the API client to delete the preference
 	err := r.client.preference.DeletePreference(ctx, resourceID)
+	if response.IsNotFound(err) {
+		// Already gone, nothing left to delete
+		tflog.Warn(ctx, "Preference already deleted", map[string]interface{}{
+			"resource_id": resourceID,
+		})
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Preference",
@@ -408,21 +582,15 @@ func (r *preferenceResource) convertToAPIModel(tfModel *preferenceResourceModel)
 		apiModel.Description = tfModel.Description.ValueString()
 	}
 
-	if !tfModel.Version.IsNull() {
+	// last_updated is set by the API and never sent
+	if !tfModel.Version.IsNull() && !tfModel.Version.IsUnknown() {
 		apiModel.Version = tfModel.Version.ValueString()
 	}
 
-	if !tfModel.LastUpdated.IsNull() && tfModel.LastUpdated.ValueString() != "" {
-		parsedTime, err := time.Parse(time.RFC3339, tfModel.LastUpdated.ValueString())
-		if err == nil {
-			apiModel.LastUpdated = &parsedTime
-		}
-	}
-
 	// Convert AvailabilityThresholds
 	if tfModel.AvailabilityThresholds != nil {
 		if !tfModel.AvailabilityThresholds.World.IsNull() {
-			apiModel.AvailabilityThresholds.World = tfModel.AvailabilityThresholds.World.ValueInt64()
+			apiModel.AvailabilityThresholds.World = tfModel.AvailabilityThresholds.World.ValueFloat64()
 		}
 
 		if tfModel.AvailabilityThresholds.Continents != nil {
@@ -432,14 +600,14 @@ func (r *preferenceResource) convertToAPIModel(tfModel *preferenceResourceModel)
 				apiContinent := preferenceclient.ContinentThreshold{}
 
 				if !tfContinent.Default.IsNull() {
-					apiContinent.Default = tfContinent.Default.ValueInt64()
+					apiContinent.Default = tfContinent.Default.ValueFloat64()
 				}
 
 				if tfContinent.Countries != nil {
-					apiContinent.Countries = make(map[string]int64)
+					apiContinent.Countries = make(map[string]float64)
 					for country, threshold := range tfContinent.Countries {
 						if !threshold.IsNull() {
-							apiContinent.Countries[country] = threshold.ValueInt64()
+							apiContinent.Countries[country] = threshold.ValueFloat64()
 						}
 					}
 				}
@@ -549,7 +717,7 @@ func (r *preferenceResource) convertFromAPIModel(apiModel *preferenceclient.Pref
 
 	// Convert AvailabilityThresholds
 	tfModel.AvailabilityThresholds = &availabilityThresholdsModel{
-		World:      types.Int64Value(apiModel.AvailabilityThresholds.World),
+		World:      types.Float64Value(apiModel.AvailabilityThresholds.World),
 		Continents: nil, // Initialize as nil, not empty map
 	}
 
@@ -559,16 +727,16 @@ func (r *preferenceResource) convertFromAPIModel(apiModel *preferenceclient.Pref
 
 		for continent, apiContinent := range apiModel.AvailabilityThresholds.Continents {
 			tfContinent := &continentThresholdModel{
-				Default:   types.Int64Value(apiContinent.Default),
+				Default:   types.Float64Value(apiContinent.Default),
 				Countries: nil, // Initialize as nil, not empty map
 			}
 
 			// Only initialize countries map if there are actual countries
 			if len(apiContinent.Countries) > 0 {
-				tfContinent.Countries = make(map[string]types.Int64)
+				tfContinent.Countries = make(map[string]types.Float64)
 
 				for country, threshold := range apiContinent.Countries {
-					tfContinent.Countries[country] = types.Int64Value(threshold)
+					tfContinent.Countries[country] = types.Float64Value(threshold)
 				}
 			}
 
@@ -1,18 +1,50 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/multicdntest"
 	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
+// Test that the configurations of the acceptance tests pass plan-time validation, without Terraform.
+// The comprehensive fixtures set relative thresholds in absolute mode, which only warns.
+func TestPreferenceResource_fixturesValidate(t *testing.T) {
+	fixtures := map[string]string{
+		"default":               testAccPreferenceResourceConfig("http://localhost", "Test Description"),
+		"updated":               testAccPreferenceResourceUpdateConfig("http://localhost", "Test Description"),
+		"comprehensive":         testAccPreferenceResourceConfigComprehensive("http://localhost", "Test Description"),
+		"comprehensive_updated": testAccPreferenceResourceConfigComprehensiveUpdated("http://localhost", "Test Description"),
+		"minimal":               testAccPreferenceResourceConfigMinimal("http://localhost"),
+		"timeouts":              testAccPreferenceResourceConfigWithTimeouts("http://localhost", "5m"),
+		"without_provider":      testAccPreferenceResourceConfigWithoutProvider(),
+	}
+
+	for name, config := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			diags := validateResourceConfig(t, "multicdn_preference_config", hclResourceConfig(t, config, "multicdn_preference_config"))
+			for _, d := range diags {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Errorf("Unexpected error: %s: %s", d.Summary, d.Detail)
+				}
+			}
+		})
+	}
+}
+
 // Basic acceptance test for preference resource
 func TestAccPreferenceResource_basic(t *testing.T) {
-	mockServer, mockPreferences, factories := setupAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -25,10 +57,10 @@ func TestAccPreferenceResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("multicdn_preference_config.test", "resource_id", "12345"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.test", "content_type", "application/json"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.test", "description", "Test Description"),
-					resource.TestCheckNoResourceAttr("multicdn_preference_config.test", "version"),
-					resource.TestCheckNoResourceAttr("multicdn_preference_config.test", "last_updated"),
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "version", "1"),
+					resource.TestCheckResourceAttrSet("multicdn_preference_config.test", "last_updated"),
 					// Check that the preference exists in our mock store
-					testAccCheckPreferenceExists(12345, mockPreferences),
+					testAccCheckPreferenceExists(12345, mockServer),
 				),
 			},
 			// Update testing
@@ -36,7 +68,15 @@ func TestAccPreferenceResource_basic(t *testing.T) {
 				Config: testAccPreferenceResourceConfig(mockServer.URL, "Updated Description"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("multicdn_preference_config.test", "description", "Updated Description"),
-					testAccCheckPreferenceDescription(12345, "Updated Description", mockPreferences),
+					testAccCheckPreferenceDescription(12345, "Updated Description", mockServer),
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "version", "2"),
+				),
+			},
+			// Applying the same configuration again changes nothing and keeps the values set by the API
+			{
+				Config: testAccPreferenceResourceConfig(mockServer.URL, "Updated Description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckPreferenceLastUpdated("multicdn_preference_config.test", 12345, mockServer),
 				),
 			},
 			// Import testing
@@ -53,7 +93,7 @@ func TestAccPreferenceResource_basic(t *testing.T) {
 
 // Test for error cases
 func TestAccPreferenceResource_errors(t *testing.T) {
-	mockServer, _, factories := setupAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -70,7 +110,7 @@ func TestAccPreferenceResource_errors(t *testing.T) {
 
 // Test for complete workflow
 func TestAccPreferenceResource_completeLifecycle(t *testing.T) {
-	mockServer, mockPreferences, factories := setupAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	var resourceID string
@@ -84,7 +124,7 @@ func TestAccPreferenceResource_completeLifecycle(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckPreferenceResourceExists("multicdn_preference_config.test", &resourceID),
 					resource.TestCheckResourceAttr("multicdn_preference_config.test", "description", "Initial Resource"),
-					testAccCheckPreferenceExists(12345, mockPreferences),
+					testAccCheckPreferenceExists(12345, mockServer),
 				),
 			},
 			// Update the resource
@@ -94,10 +134,10 @@ func TestAccPreferenceResource_completeLifecycle(t *testing.T) {
 					testAccCheckPreferenceResourceExists("multicdn_preference_config.test", &resourceID),
 					resource.TestCheckResourceAttr("multicdn_preference_config.test", "description", "Updated Resource"),
 					// Check updated availability thresholds
-					resource.TestCheckResourceAttr("multicdn_preference_config.test", "availability_thresholds.world", "96"),
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "availability_thresholds.world", "99.5"),
 					// Check updated performance filtering
 					resource.TestCheckResourceAttr("multicdn_preference_config.test", "performance_filtering.world.relative_threshold", "0"),
-					testAccCheckPreferenceWorld(12345, 96, mockPreferences),
+					testAccCheckPreferenceWorld(12345, 99.5, mockServer),
 				),
 			},
 			// Import the resource
@@ -111,13 +151,13 @@ func TestAccPreferenceResource_completeLifecycle(t *testing.T) {
 			// Delete happens automatically after all steps
 			// Let's verify it's gone from our mock store after the test
 		},
-		CheckDestroy: testAccCheckPreferenceDestroyed(mockPreferences),
+		CheckDestroy: testAccCheckPreferenceDestroyed(mockServer),
 	})
 }
 
 // Test for configuration with all nested fields and multiple values
 func TestAccPreferenceResource_comprehensive(t *testing.T) {
-	mockServer, mockPreferences, factories := setupAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -127,12 +167,12 @@ func TestAccPreferenceResource_comprehensive(t *testing.T) {
 			{
 				Config: testAccPreferenceResourceConfigComprehensive(mockServer.URL, "Comprehensive Config"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckPreferenceExists(54321, mockPreferences),
+					testAccCheckPreferenceExists(54321, mockServer),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "resource_id", "54321"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "content_type", "application/json"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "description", "Comprehensive Config"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "version", "1.0"),
-					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "last_updated", "2025-08-01T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("multicdn_preference_config.comprehensive", "last_updated"),
 					// Check availability thresholds - Using the exact string representation without trailing zeros
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "availability_thresholds.world", "95"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "availability_thresholds.continents.NA.default", "98"),
@@ -157,10 +197,10 @@ func TestAccPreferenceResource_comprehensive(t *testing.T) {
 			{
 				Config: testAccPreferenceResourceConfigComprehensiveUpdated(mockServer.URL, "Updated Comprehensive Config"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckPreferenceExists(54321, mockPreferences),
+					testAccCheckPreferenceExists(54321, mockServer),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "description", "Updated Comprehensive Config"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "version", "1.1"),
-					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "last_updated", "2025-08-02T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("multicdn_preference_config.comprehensive", "last_updated"),
 					// Check updated availability thresholds
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "availability_thresholds.world", "96"),
 					resource.TestCheckResourceAttr("multicdn_preference_config.comprehensive", "availability_thresholds.continents.NA.default", "99"),
@@ -177,7 +217,7 @@ func TestAccPreferenceResource_comprehensive(t *testing.T) {
 
 // Test for minimal configuration with only required fields
 func TestAccPreferenceResource_minimal(t *testing.T) {
-	mockServer, mockPreferences, factories := setupAccProtoV6ProviderFactories()
+	mockServer, factories := setupAccProtoV6ProviderFactories()
 	defer mockServer.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -187,7 +227,7 @@ func TestAccPreferenceResource_minimal(t *testing.T) {
 			{
 				Config: testAccPreferenceResourceConfigMinimal(mockServer.URL),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckPreferenceExists(98765, mockPreferences),
+					testAccCheckPreferenceExists(98765, mockServer),
 					resource.TestCheckResourceAttr("multicdn_preference_config.minimal", "resource_id", "98765"),
 					// Check minimal availability thresholds - using exact string representation
 					resource.TestCheckResourceAttr("multicdn_preference_config.minimal", "availability_thresholds.world", "95"),
@@ -202,6 +242,370 @@ func TestAccPreferenceResource_minimal(t *testing.T) {
 	})
 }
 
+// Test that a preference deleted outside of Terraform is planned for re-creation
+func TestAccPreferenceResource_deletedOutOfBand(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			// Create the preference
+			{
+				Config: testAccPreferenceResourceConfig(mockServer.URL, "Test Description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckPreferenceExists(12345, mockServer),
+				),
+			},
+			// Remove it from the backend and expect a plan to re-create it
+			{
+				PreConfig: func() {
+					mockServer.DeletePreference(12345)
+				},
+				Config:             testAccPreferenceResourceConfig(mockServer.URL, "Test Description"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Apply re-creates the preference
+			{
+				Config: testAccPreferenceResourceConfig(mockServer.URL, "Test Description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckPreferenceExists(12345, mockServer),
+				),
+			},
+			// Destroy succeeds even if the preference is already gone
+			{
+				PreConfig: func() {
+					mockServer.DeletePreference(12345)
+				},
+				Config:  testAccPreferenceResourceConfig(mockServer.URL, "Test Description"),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+// Test that updates do not overwrite a preference modified since the plan was made.
+// Terraform refreshes the state before planning, so the update is applied directly at the protocol level
+// with a prior state that is older than the stored preference.
+func TestPreferenceResource_optimisticConcurrency(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name                  string
+		storedVersion         string
+		optimisticConcurrency string
+		fault                 *multicdntest.Fault
+		expectSummary         string
+		expectDetail          string
+	}{
+		{
+			name:          "unchanged",
+			storedVersion: "1.0",
+		},
+		{
+			name:          "modified_outside_terraform",
+			storedVersion: "2.0",
+			expectSummary: "Configuration Modified Outside Terraform",
+			expectDetail:  `was modified outside this Terraform run: it no longer has version "1.0"`,
+		},
+		{
+			name:                  "disabled",
+			storedVersion:         "2.0",
+			optimisticConcurrency: `"optimistic_concurrency": false`,
+		},
+		{
+			// Only 412 Precondition Failed answers If-Match, so other errors are reported as they are
+			name:          "conflict",
+			storedVersion: "1.0",
+			fault:         &multicdntest.Fault{Method: http.MethodPut, StatusCode: http.StatusConflict, Body: `{"message": "Locked"}`},
+			expectSummary: "Error Updating Preference",
+			expectDetail:  "Unable to update preference ID 12345",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockServer.SetPreference(preferenceclient.Preference{
+				ResourceID:  12345,
+				Description: "Changed elsewhere",
+				Version:     tc.storedVersion,
+			})
+			if tc.fault != nil {
+				mockServer.InjectFault(*tc.fault)
+				defer mockServer.ClearFaults()
+			}
+
+			server := configureTestProvider(t, mockServer.URL, tc.optimisticConcurrency)
+
+			applyResp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "multicdn_preference_config",
+				PriorState:   testPreferenceValue(`"description": "Test Description", "version": "1.0"`),
+				PlannedState: testPreferenceValue(`"description": "Updated Description", "version": "1.0"`),
+				Config:       testPreferenceValue(`"description": "Updated Description", "version": "1.0"`),
+			})
+			if err != nil {
+				t.Fatalf("ApplyResourceChange returned an error: %v", err)
+			}
+
+			expectDiagnostic(t, applyResp.Diagnostics, tfprotov6.DiagnosticSeverityError, tc.expectSummary, tc.expectDetail)
+
+			expectedDescription := "Updated Description"
+			if tc.expectSummary != "" {
+				expectedDescription = "Changed elsewhere"
+			}
+			if stored, _ := mockServer.Preference(12345); stored.Description != expectedDescription {
+				t.Errorf("Expected stored description %q, got %q", expectedDescription, stored.Description)
+			}
+		})
+	}
+}
+
+// Test that creating a preference that already exists fails, unless the provider adopts existing configurations
+func TestPreferenceResource_adoptExisting(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name          string
+		adoptExisting string
+		expectError   string
+		expectWarning string
+	}{
+		{
+			name:        "default",
+			expectError: "A preference with ID 12345 already exists",
+		},
+		{
+			name:          "adopted",
+			adoptExisting: `"adopt_existing": true`,
+			expectWarning: `A preference with ID 12345 already existed, so it was adopted instead of created: its previous version "3.0"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockServer.SetPreference(preferenceclient.Preference{
+				ResourceID:  12345,
+				Description: "Created elsewhere",
+				Version:     "3.0",
+			})
+
+			server := configureTestProvider(t, mockServer.URL, tc.adoptExisting)
+
+			preference := testPreferenceValue(`"description": "Adopted Description"`)
+			applyResp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "multicdn_preference_config",
+				PriorState:   jsonValue("null"),
+				PlannedState: preference,
+				Config:       preference,
+			})
+			if err != nil {
+				t.Fatalf("ApplyResourceChange returned an error: %v", err)
+			}
+			if len(applyResp.Diagnostics) != 1 {
+				t.Fatalf("Expected a single diagnostic, got %v", applyResp.Diagnostics)
+			}
+
+			diagnostic := applyResp.Diagnostics[0]
+			expectedDescription := "Adopted Description"
+			if tc.expectError != "" {
+				if diagnostic.Severity != tfprotov6.DiagnosticSeverityError || !strings.Contains(diagnostic.Detail, tc.expectError) {
+					t.Fatalf("Expected an error containing %q, got %s: %s", tc.expectError, diagnostic.Summary, diagnostic.Detail)
+				}
+				expectedDescription = "Created elsewhere"
+			} else {
+				if diagnostic.Severity != tfprotov6.DiagnosticSeverityWarning || !strings.Contains(diagnostic.Detail, tc.expectWarning) {
+					t.Fatalf("Expected a warning containing %q, got %s: %s", tc.expectWarning, diagnostic.Summary, diagnostic.Detail)
+				}
+				if applyResp.NewState == nil {
+					t.Errorf("Expected the adopted preference to be recorded in state")
+				}
+				if stored, _ := mockServer.Preference(12345); stored.Version != "3.1" {
+					t.Errorf("Expected the update to be based on the existing version 3.0, got version %q", stored.Version)
+				}
+			}
+
+			if stored, _ := mockServer.Preference(12345); stored.Description != expectedDescription {
+				t.Errorf("Expected stored description %q, got %q", expectedDescription, stored.Description)
+			}
+		})
+	}
+}
+
+// Test that deletion_protection defaults to the provider setting and blocks deletes until it is disabled in state
+func TestPreferenceResource_deletionProtection(t *testing.T) {
+	mockServer, _ := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name               string
+		providerDefault    string
+		deletionProtection string
+		expectPlanned      bool
+		expectError        bool
+	}{
+		{
+			name:               "unprotected",
+			deletionProtection: "false",
+		},
+		{
+			name:               "protected",
+			deletionProtection: "true",
+			expectPlanned:      true,
+			expectError:        true,
+		},
+		{
+			name:            "provider_default",
+			providerDefault: `"deletion_protection": true`,
+			expectPlanned:   true,
+			expectError:     true,
+		},
+		{
+			name:               "disabled_despite_provider_default",
+			providerDefault:    `"deletion_protection": true`,
+			deletionProtection: "false",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockServer.SetPreference(preferenceclient.Preference{ResourceID: 12345, Version: "1.0"})
+
+			ctx := context.Background()
+			server := configureTestProvider(t, mockServer.URL, tc.providerDefault)
+
+			deletionProtection := "null"
+			if tc.deletionProtection != "" {
+				deletionProtection = tc.deletionProtection
+			}
+			preference := testPreferenceValue(`"deletion_protection": ` + deletionProtection)
+
+			// Unless configured, the provider default is planned
+			planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "multicdn_preference_config",
+				PriorState:       jsonValue("null"),
+				ProposedNewState: preference,
+				Config:           preference,
+			})
+			if err != nil || len(planResp.Diagnostics) > 0 {
+				t.Fatalf("Failed to plan the preference: %v %v", err, planResp.Diagnostics)
+			}
+
+			planned := decodeResourceValue(t, server, "multicdn_preference_config", planResp.PlannedState)
+			var attributes map[string]tftypes.Value
+			var protected bool
+			if err := planned.As(&attributes); err != nil {
+				t.Fatalf("Failed to decode the planned attributes: %v", err)
+			}
+			if err := attributes["deletion_protection"].As(&protected); err != nil || protected != tc.expectPlanned {
+				t.Errorf("Expected deletion_protection %t to be planned, got %v (error: %v)", tc.expectPlanned, attributes["deletion_protection"], err)
+			}
+
+			// The delete is refused based on the prior state
+			applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "multicdn_preference_config",
+				PriorState:   preference,
+				PlannedState: jsonValue("null"),
+				Config:       jsonValue("null"),
+			})
+			if err != nil {
+				t.Fatalf("ApplyResourceChange returned an error: %v", err)
+			}
+
+			_, exists := mockServer.Preference(12345)
+			if tc.expectError {
+				if len(applyResp.Diagnostics) != 1 || applyResp.Diagnostics[0].Summary != "Deletion Protection Enabled" {
+					t.Fatalf("Expected a deletion protection error, got %v", applyResp.Diagnostics)
+				}
+				if !exists {
+					t.Error("Expected the protected preference to be kept")
+				}
+			} else {
+				if len(applyResp.Diagnostics) > 0 {
+					t.Fatalf("Unexpected diagnostics: %v", applyResp.Diagnostics)
+				}
+				if exists {
+					t.Error("Expected the preference to be deleted")
+				}
+			}
+		})
+	}
+}
+
+// Test that operation timeouts are accepted and validated
+func TestAccPreferenceResource_timeouts(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPreferenceResourceConfigWithTimeouts(mockServer.URL, "soon"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Time Duration`),
+			},
+			{
+				Config: testAccPreferenceResourceConfigWithTimeouts(mockServer.URL, "5m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckPreferenceExists(12345, mockServer),
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "timeouts.create", "5m"),
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "timeouts.update", "5m"),
+					resource.TestCheckNoResourceAttr("multicdn_preference_config.test", "timeouts.read"),
+				),
+			},
+		},
+	})
+}
+
+// Test that requests to an unresponsive API are bounded by the request and operation timeouts
+func TestPreferenceResource_hungRequestTimeouts(t *testing.T) {
+	hungServer := multicdntest.NewServer()
+	defer hungServer.Close()
+
+	hungServer.InjectFault(multicdntest.Fault{Latency: time.Hour})
+
+	tests := []struct {
+		name           string
+		providerConfig string
+		timeouts       string
+		expectError    string
+	}{
+		{
+			name:           "request_timeout",
+			providerConfig: `"request_timeout": "50ms", "retry_max_attempts": 1`,
+			timeouts:       `null`,
+			expectError:    "no response within the request timeout of 50ms",
+		},
+		{
+			name:           "read_timeout",
+			providerConfig: `"request_timeout": "0s"`,
+			timeouts:       `{"read": "50ms"}`,
+			expectError:    "context deadline exceeded",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := configureTestProvider(t, hungServer.URL, tc.providerConfig)
+
+			readResp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+				TypeName:     "multicdn_preference_config",
+				CurrentState: testPreferenceValue(`"timeouts": ` + tc.timeouts),
+			})
+			if err != nil {
+				t.Fatalf("ReadResource returned an error: %v", err)
+			}
+
+			if len(readResp.Diagnostics) != 1 || readResp.Diagnostics[0].Summary != "Error Reading Preference" ||
+				!strings.Contains(readResp.Diagnostics[0].Detail, tc.expectError) {
+				t.Fatalf("Expected a read error containing %q, got %v", tc.expectError, readResp.Diagnostics)
+			}
+		})
+	}
+}
+
 // Helper function to check if the resource exists in Terraform state
 func testAccCheckPreferenceResourceExists(resourceName string, resourceID *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -221,9 +625,9 @@ func testAccCheckPreferenceResourceExists(resourceName string, resourceID *strin
 }
 
 // Helper function to verify preference exists in mock store
-func testAccCheckPreferenceExists(resourceID int64, mockPreferences map[int64]*preferenceclient.Preference) resource.TestCheckFunc {
+func testAccCheckPreferenceExists(resourceID int64, mockServer *multicdntest.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		if pref, exists := mockPreferences[resourceID]; !exists {
+		if pref, exists := mockServer.Preference(resourceID); !exists {
 			return fmt.Errorf("preference with ID %d does not exist in mock store", resourceID)
 		} else if pref == nil {
 			return fmt.Errorf("preference with ID %d is nil", resourceID)
@@ -233,9 +637,9 @@ func testAccCheckPreferenceExists(resourceID int64, mockPreferences map[int64]*p
 }
 
 // Helper function to verify description was updated
-func testAccCheckPreferenceDescription(resourceID int64, expected string, mockPreferences map[int64]*preferenceclient.Preference) resource.TestCheckFunc {
+func testAccCheckPreferenceDescription(resourceID int64, expected string, mockServer *multicdntest.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		pref, exists := mockPreferences[resourceID]
+		pref, exists := mockServer.Preference(resourceID)
 		if !exists {
 			return fmt.Errorf("preference with ID %d does not exist in mock store", resourceID)
 		}
@@ -246,15 +650,26 @@ func testAccCheckPreferenceDescription(resourceID int64, expected string, mockPr
 	}
 }
 
+// Helper function to verify that last_updated in state matches the timestamp set by the API
+func testAccCheckPreferenceLastUpdated(resourceName string, resourceID int64, mockServer *multicdntest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pref, exists := mockServer.Preference(resourceID)
+		if !exists || pref.LastUpdated == nil {
+			return fmt.Errorf("preference with ID %d does not exist or has no last updated timestamp", resourceID)
+		}
+		return resource.TestCheckResourceAttr(resourceName, "last_updated", pref.LastUpdated.Format(time.RFC3339))(s)
+	}
+}
+
 // Helper function to verify world threshold was updated
-func testAccCheckPreferenceWorld(resourceID int64, expected int64, mockPreferences map[int64]*preferenceclient.Preference) resource.TestCheckFunc {
+func testAccCheckPreferenceWorld(resourceID int64, expected float64, mockServer *multicdntest.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		pref, exists := mockPreferences[resourceID]
+		pref, exists := mockServer.Preference(resourceID)
 		if !exists {
 			return fmt.Errorf("preference with ID %d does not exist in mock store", resourceID)
 		}
 		if pref.AvailabilityThresholds.World != expected {
-			return fmt.Errorf("preference world threshold is %d, expected %d",
+			return fmt.Errorf("preference world threshold is %g, expected %g",
 				pref.AvailabilityThresholds.World, expected)
 		}
 		return nil
@@ -262,9 +677,9 @@ func testAccCheckPreferenceWorld(resourceID int64, expected int64, mockPreferenc
 }
 
 // Helper to check that the resource is destroyed
-func testAccCheckPreferenceDestroyed(mockPreferences map[int64]*preferenceclient.Preference) resource.TestCheckFunc {
+func testAccCheckPreferenceDestroyed(mockServer *multicdntest.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		if pref, exists := mockPreferences[12345]; exists {
+		if pref, exists := mockServer.Preference(12345); exists {
 			return fmt.Errorf("preference with ID 12345 still exists after destroy: %v", pref)
 		}
 		return nil
@@ -343,7 +758,7 @@ resource "multicdn_preference_config" "test" {
   description = "%s"
   
   availability_thresholds = {
-    world = 96  # Updated value
+    world = 99.5  # Updated value
     continents = {
       "NA" = {
         default = 98
@@ -450,7 +865,6 @@ resource "multicdn_preference_config" "comprehensive" {
   content_type = "application/json"
   description = "%s"
   version = "1.0"
-  last_updated = "2025-08-01T00:00:00Z"
   
   // Comprehensive availability thresholds with multiple continents and countries
   availability_thresholds = {
@@ -470,7 +884,7 @@ resource "multicdn_preference_config" "comprehensive" {
         countries = {
           "DE" = 98
           "FR" = 97
-          "UK" = 96
+          "GB" = 96
           "IT" = 96
         }
       }
@@ -569,7 +983,6 @@ resource "multicdn_preference_config" "comprehensive" {
   content_type = "application/json"
   description = "%s"
   version = "1.1"
-  last_updated = "2025-08-02T00:00:00Z"
   
   // Updated availability thresholds
   availability_thresholds = {
@@ -589,7 +1002,7 @@ resource "multicdn_preference_config" "comprehensive" {
         countries = {
           "DE" = 95  // Changed from 98
           "FR" = 94  // Changed from 97
-          "UK" = 93  // Changed from 96
+          "GB" = 93  // Changed from 96
           "ES" = 92  // Added new country
         }
       }
@@ -713,6 +1126,7 @@ resource "multicdn_preference_config" "minimal" {
     continents = {
       "NA" = {
         mode = "relative"
+        relative_threshold = 0.1
         countries = {
           "US" = {
             mode = "relative"
@@ -730,3 +1144,39 @@ resource "multicdn_preference_config" "minimal" {
 }
 `, serverURL)
 }
+
+// Preference configuration with create and update timeouts
+func testAccPreferenceResourceConfigWithTimeouts(mockServerURL, timeout string) string {
+	return fmt.Sprintf(`
+provider "multicdn" {
+  api_key = "test-key"
+  api_secret = "test-secret"
+  base_url = "%s"
+}
+
+resource "multicdn_preference_config" "test" {
+  resource_id = 12345
+
+  availability_thresholds = {
+    world = 95
+    continents = {}
+  }
+
+  performance_filtering = {
+    world = {
+      mode = "absolute"
+    }
+    continents = {}
+  }
+
+  enabled_subdivision_countries = {
+    continents = {}
+  }
+
+  timeouts = {
+    create = "%s"
+    update = "%s"
+  }
+}
+`, mockServerURL, timeout, timeout)
+}
@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// preferenceResourceModelV0 maps the version 0 resource schema, which stored availability thresholds as whole numbers.
+// It is a frozen copy of the model at the time and must not change with the current model.
+type preferenceResourceModelV0 struct {
+	ResourceID                  types.Int64                         `tfsdk:"resource_id"`
+	ContentType                 types.String                        `tfsdk:"content_type"`
+	Description                 types.String                        `tfsdk:"description"`
+	Version                     types.String                        `tfsdk:"version"`
+	LastUpdated                 types.String                        `tfsdk:"last_updated"`
+	AvailabilityThresholds      *availabilityThresholdsModelV0      `tfsdk:"availability_thresholds"`
+	PerformanceFiltering        *performanceFilteringModelV0        `tfsdk:"performance_filtering"`
+	EnabledSubdivisionCountries *enabledSubdivisionCountriesModelV0 `tfsdk:"enabled_subdivision_countries"`
+}
+
+// availabilityThresholdsModelV0 maps the version 0 AvailabilityThresholds schema
+type availabilityThresholdsModelV0 struct {
+	World      types.Int64                           `tfsdk:"world"`
+	Continents map[string]*continentThresholdModelV0 `tfsdk:"continents"`
+}
+
+// continentThresholdModelV0 maps the version 0 ContinentThreshold schema
+type continentThresholdModelV0 struct {
+	Default   types.Int64            `tfsdk:"default"`
+	Countries map[string]types.Int64 `tfsdk:"countries"`
+}
+
+// performanceFilteringModelV0 maps the version 0 PerformanceFiltering schema
+type performanceFilteringModelV0 struct {
+	World      *performanceConfigModelV0                     `tfsdk:"world"`
+	Continents map[string]*continentPerformanceConfigModelV0 `tfsdk:"continents"`
+}
+
+// performanceConfigModelV0 maps the version 0 PerformanceConfig schema
+type performanceConfigModelV0 struct {
+	Mode              types.String  `tfsdk:"mode"`
+	RelativeThreshold types.Float64 `tfsdk:"relative_threshold"`
+}
+
+// continentPerformanceConfigModelV0 maps the version 0 ContinentPerformanceConfig schema
+type continentPerformanceConfigModelV0 struct {
+	Mode              types.String                         `tfsdk:"mode"`
+	RelativeThreshold types.Float64                        `tfsdk:"relative_threshold"`
+	Countries         map[string]*performanceConfigModelV0 `tfsdk:"countries"`
+}
+
+// enabledSubdivisionCountriesModelV0 maps the version 0 EnabledSubdivisionCountries schema
+type enabledSubdivisionCountriesModelV0 struct {
+	Continents map[string]*continentSubdivisionsModelV0 `tfsdk:"continents"`
+}
+
+// continentSubdivisionsModelV0 maps the version 0 ContinentSubdivisions schema
+type continentSubdivisionsModelV0 struct {
+	Countries []types.String `tfsdk:"countries"`
+}
+
+// UpgradeState migrates state written by earlier versions of the resource schema
+func (r *preferenceResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := preferenceResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradePreferenceStateV0,
+		},
+	}
+}
+
+// preferenceResourceSchemaV0 returns the version 0 schema. It is a frozen copy of the schema at the time
+// and must not change with the current schema.
+func preferenceResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Description: "Manages a CDN preference configuration",
+		Attributes: map[string]schema.Attribute{
+			"resource_id": schema.Int64Attribute{
+				Description: "Unique ID of the CDN preference configuration",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"content_type": schema.StringAttribute{
+				Description: "Content type of the CDN preference configuration",
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the CDN preference configuration",
+				Optional:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the CDN preference configuration",
+				Optional:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of when the configuration was last updated",
+				Optional:    true,
+			},
+			"availability_thresholds": schema.SingleNestedAttribute{
+				Description: "Availability thresholds configuration",
+				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"world": schema.Int64Attribute{
+						Description: "Global availability threshold (0-100)",
+						Optional:    true,
+					},
+					"continents": schema.MapNestedAttribute{
+						Description: "Continent-specific availability thresholds",
+						Required:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"default": schema.Int64Attribute{
+									Description: "Default threshold for the continent (0-100)",
+									Required:    true,
+								},
+								"countries": schema.MapAttribute{
+									Description: "Country-specific thresholds (0-100)",
+									Required:    true,
+									ElementType: types.Int64Type,
+								},
+							},
+						},
+					},
+				},
+			},
+			"performance_filtering": schema.SingleNestedAttribute{
+				Description: "Performance filtering configuration",
+				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"world": schema.SingleNestedAttribute{
+						Description: "Global performance filtering configuration",
+						Required:    true,
+						Attributes: map[string]schema.Attribute{
+							"mode": schema.StringAttribute{
+								Description: "Performance filtering mode (relative or absolute)",
+								Required:    true,
+							},
+							"relative_threshold": schema.Float64Attribute{
+								Description: "Relative performance threshold",
+								Optional:    true,
+							},
+						},
+					},
+					"continents": schema.MapNestedAttribute{
+						Description: "Continent-specific performance configurations",
+						Required:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"mode": schema.StringAttribute{
+									Description: "Performance filtering mode for the continent",
+									Required:    true,
+								},
+								"relative_threshold": schema.Float64Attribute{
+									Description: "Relative performance threshold for the continent",
+									Optional:    true,
+								},
+								"countries": schema.MapNestedAttribute{
+									Description: "Country-specific performance configurations",
+									Required:    true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"mode": schema.StringAttribute{
+												Description: "Performance filtering mode for the country",
+												Required:    true,
+											},
+											"relative_threshold": schema.Float64Attribute{
+												Description: "Relative performance threshold for the country",
+												Optional:    true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"enabled_subdivision_countries": schema.SingleNestedAttribute{
+				Description: "Configuration for countries with enabled subdivisions",
+				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"continents": schema.MapNestedAttribute{
+						Description: "Continent-specific subdivision configurations",
+						Required:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"countries": schema.ListAttribute{
+									Description: "List of countries with enabled subdivisions",
+									Required:    true,
+									ElementType: types.StringType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// upgradePreferenceStateV0 converts whole number availability thresholds to decimals.
+// Attributes added since version 0, such as timeouts and deletion_protection, are null.
+func upgradePreferenceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior preferenceResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := preferenceResourceModel{
+		ResourceID:                  prior.ResourceID,
+		ContentType:                 prior.ContentType,
+		Description:                 prior.Description,
+		Version:                     prior.Version,
+		LastUpdated:                 prior.LastUpdated,
+		Timeouts:                    nullTimeouts(ctx),
+		PerformanceFiltering:        upgradePerformanceFilteringV0(prior.PerformanceFiltering),
+		EnabledSubdivisionCountries: upgradeEnabledSubdivisionCountriesV0(prior.EnabledSubdivisionCountries),
+	}
+
+	if prior.AvailabilityThresholds != nil {
+		upgraded.AvailabilityThresholds = &availabilityThresholdsModel{
+			World: int64ToFloat64(prior.AvailabilityThresholds.World),
+		}
+
+		if prior.AvailabilityThresholds.Continents != nil {
+			upgraded.AvailabilityThresholds.Continents = make(map[string]*continentThresholdModel, len(prior.AvailabilityThresholds.Continents))
+			for continent, priorContinent := range prior.AvailabilityThresholds.Continents {
+				if priorContinent == nil {
+					upgraded.AvailabilityThresholds.Continents[continent] = nil
+					continue
+				}
+
+				upgradedContinent := &continentThresholdModel{
+					Default: int64ToFloat64(priorContinent.Default),
+				}
+				if priorContinent.Countries != nil {
+					upgradedContinent.Countries = make(map[string]types.Float64, len(priorContinent.Countries))
+					for country, threshold := range priorContinent.Countries {
+						upgradedContinent.Countries[country] = int64ToFloat64(threshold)
+					}
+				}
+
+				upgraded.AvailabilityThresholds.Continents[continent] = upgradedContinent
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// upgradePerformanceFilteringV0 copies the version 0 performance filtering, which is unchanged in the current schema
+func upgradePerformanceFilteringV0(prior *performanceFilteringModelV0) *performanceFilteringModel {
+	if prior == nil {
+		return nil
+	}
+
+	upgraded := &performanceFilteringModel{
+		World: upgradePerformanceConfigV0(prior.World),
+	}
+	if prior.Continents != nil {
+		upgraded.Continents = make(map[string]*continentPerformanceConfigModel, len(prior.Continents))
+		for continent, priorContinent := range prior.Continents {
+			if priorContinent == nil {
+				upgraded.Continents[continent] = nil
+				continue
+			}
+
+			upgradedContinent := &continentPerformanceConfigModel{
+				Mode:              priorContinent.Mode,
+				RelativeThreshold: priorContinent.RelativeThreshold,
+			}
+			if priorContinent.Countries != nil {
+				upgradedContinent.Countries = make(map[string]*performanceConfigModel, len(priorContinent.Countries))
+				for country, priorCountry := range priorContinent.Countries {
+					upgradedContinent.Countries[country] = upgradePerformanceConfigV0(priorCountry)
+				}
+			}
+
+			upgraded.Continents[continent] = upgradedContinent
+		}
+	}
+
+	return upgraded
+}
+
+// upgradePerformanceConfigV0 copies a version 0 performance configuration
+func upgradePerformanceConfigV0(prior *performanceConfigModelV0) *performanceConfigModel {
+	if prior == nil {
+		return nil
+	}
+	return &performanceConfigModel{
+		Mode:              prior.Mode,
+		RelativeThreshold: prior.RelativeThreshold,
+	}
+}
+
+// upgradeEnabledSubdivisionCountriesV0 copies the version 0 subdivision countries, which are unchanged in the current schema
+func upgradeEnabledSubdivisionCountriesV0(prior *enabledSubdivisionCountriesModelV0) *enabledSubdivisionCountriesModel {
+	if prior == nil {
+		return nil
+	}
+
+	upgraded := &enabledSubdivisionCountriesModel{}
+	if prior.Continents != nil {
+		upgraded.Continents = make(map[string]*continentSubdivisionsModel, len(prior.Continents))
+		for continent, priorContinent := range prior.Continents {
+			if priorContinent == nil {
+				upgraded.Continents[continent] = nil
+				continue
+			}
+			upgraded.Continents[continent] = &continentSubdivisionsModel{
+				Countries: priorContinent.Countries,
+			}
+		}
+	}
+
+	return upgraded
+}
+
+// int64ToFloat64 converts an Int64 value to a Float64 value, keeping null and unknown values
+func int64ToFloat64(value types.Int64) types.Float64 {
+	switch {
+	case value.IsNull():
+		return types.Float64Null()
+	case value.IsUnknown():
+		return types.Float64Unknown()
+	default:
+		return types.Float64Value(float64(value.ValueInt64()))
+	}
+}
@@ -0,0 +1,109 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/provider"
+)
+
+// Test that state written with whole number availability thresholds is upgraded to decimals
+func TestPreferenceResource_upgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(provider.New())()
+
+	upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "multicdn_preference_config",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(`{
+			"resource_id": 12345,
+			"content_type": "application/json",
+			"description": "Upgraded",
+			"version": null,
+			"last_updated": null,
+			"availability_thresholds": {
+				"world": 95,
+				"continents": {
+					"NA": {"default": 98, "countries": {"US": 99}}
+				}
+			},
+			"performance_filtering": {
+				"world": {"mode": "relative", "relative_threshold": 0.2},
+				"continents": {}
+			},
+			"enabled_subdivision_countries": {
+				"continents": {"NA": {"countries": ["US"]}}
+			}
+		}`)},
+	})
+	if err != nil {
+		t.Fatalf("UpgradeResourceState returned an error: %v", err)
+	}
+	for _, d := range upgradeResp.Diagnostics {
+		t.Errorf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if t.Failed() {
+		return
+	}
+
+	var schemaResp resource.SchemaResponse
+	provider.NewPreferenceResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	raw, err := upgradeResp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("Failed to decode upgraded state: %v", err)
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: raw}
+
+	for name, expected := range map[string]struct {
+		path  path.Path
+		value float64
+	}{
+		"world":      {path.Root("availability_thresholds").AtName("world"), 95},
+		"NA default": {path.Root("availability_thresholds").AtName("continents").AtMapKey("NA").AtName("default"), 98},
+		"US":         {path.Root("availability_thresholds").AtName("continents").AtMapKey("NA").AtName("countries").AtMapKey("US"), 99},
+	} {
+		var value types.Float64
+		if diags := state.GetAttribute(ctx, expected.path, &value); diags.HasError() {
+			t.Errorf("Failed to read %s threshold: %v", name, diags)
+			continue
+		}
+		if value.ValueFloat64() != expected.value {
+			t.Errorf("Expected %s threshold %g, got %s", name, expected.value, value)
+		}
+	}
+
+	var description types.String
+	state.GetAttribute(ctx, path.Root("description"), &description)
+	if description.ValueString() != "Upgraded" {
+		t.Errorf("Expected description to be kept, got %s", description)
+	}
+
+	var relativeThreshold types.Float64
+	state.GetAttribute(ctx, path.Root("performance_filtering").AtName("world").AtName("relative_threshold"), &relativeThreshold)
+	if relativeThreshold.ValueFloat64() != 0.2 {
+		t.Errorf("Expected the performance filtering to be kept, got relative_threshold %s", relativeThreshold)
+	}
+
+	var subdivisionCountries []types.String
+	state.GetAttribute(ctx, path.Root("enabled_subdivision_countries").AtName("continents").AtMapKey("NA").AtName("countries"), &subdivisionCountries)
+	if len(subdivisionCountries) != 1 || subdivisionCountries[0].ValueString() != "US" {
+		t.Errorf("Expected the enabled subdivision countries to be kept, got %v", subdivisionCountries)
+	}
+
+	// Attributes added after version 0 are left unset
+	for _, name := range []string{"timeouts", "deletion_protection"} {
+		var value attr.Value
+		if diags := state.GetAttribute(ctx, path.Root(name), &value); diags.HasError() || !value.IsNull() {
+			t.Errorf("Expected %s to be null, got %v %v", name, value, diags)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ValidateConfig checks the preference configuration for inconsistencies the API would only report after apply starts,
+// such as unknown geographic codes
+func (r *preferenceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config preferenceResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		// Whole collections or objects are still unknown, they are validated once known
+		return
+	}
+
+	validateAvailabilityThresholds(config.AvailabilityThresholds, &resp.Diagnostics)
+	validatePerformanceFiltering(config.PerformanceFiltering, &resp.Diagnostics)
+	validateEnabledSubdivisionCountries(config.EnabledSubdivisionCountries, &resp.Diagnostics)
+}
+
+// validateAvailabilityThresholds validates the continent and country keys of the availability thresholds.
+// The threshold values are range checked by the schema validators.
+func validateAvailabilityThresholds(thresholds *availabilityThresholdsModel, diags *diag.Diagnostics) {
+	if thresholds == nil {
+		return
+	}
+
+	thresholdsPath := path.Root("availability_thresholds")
+	for _, continentCode := range slices.Sorted(maps.Keys(thresholds.Continents)) {
+		continentPath := thresholdsPath.AtName("continents").AtMapKey(continentCode)
+		validateContinentCode(continentCode, continentPath, diags)
+
+		continent := thresholds.Continents[continentCode]
+		if continent == nil {
+			continue
+		}
+		for _, countryCode := range slices.Sorted(maps.Keys(continent.Countries)) {
+			validateCountryCode(continentCode, countryCode, continentPath.AtName("countries").AtMapKey(countryCode), diags)
+		}
+	}
+}
+
+// validatePerformanceFiltering validates the continent and country keys of the performance filtering
+// and the relative threshold of every level against its mode
+func validatePerformanceFiltering(filtering *performanceFilteringModel, diags *diag.Diagnostics) {
+	if filtering == nil {
+		return
+	}
+
+	filteringPath := path.Root("performance_filtering")
+	if filtering.World != nil {
+		validateRelativeThreshold(filtering.World.Mode, filtering.World.RelativeThreshold, filteringPath.AtName("world"), diags)
+	}
+
+	for _, continentCode := range slices.Sorted(maps.Keys(filtering.Continents)) {
+		continentPath := filteringPath.AtName("continents").AtMapKey(continentCode)
+		validateContinentCode(continentCode, continentPath, diags)
+
+		continent := filtering.Continents[continentCode]
+		if continent == nil {
+			continue
+		}
+		validateRelativeThreshold(continent.Mode, continent.RelativeThreshold, continentPath, diags)
+
+		for _, countryCode := range slices.Sorted(maps.Keys(continent.Countries)) {
+			countryPath := continentPath.AtName("countries").AtMapKey(countryCode)
+			validateCountryCode(continentCode, countryCode, countryPath, diags)
+
+			if country := continent.Countries[countryCode]; country != nil {
+				validateRelativeThreshold(country.Mode, country.RelativeThreshold, countryPath, diags)
+			}
+		}
+	}
+}
+
+// validateRelativeThreshold checks that relative_threshold is set for the relative mode and
+// warns when it is set for the absolute mode, which does not use it
+func validateRelativeThreshold(mode types.String, threshold types.Float64, configPath path.Path, diags *diag.Diagnostics) {
+	if mode.IsNull() || mode.IsUnknown() || threshold.IsUnknown() {
+		return
+	}
+
+	thresholdPath := configPath.AtName("relative_threshold")
+	switch mode.ValueString() {
+	case performanceModeRelative:
+		if threshold.IsNull() {
+			diags.AddAttributeError(
+				thresholdPath,
+				"Missing Relative Threshold",
+				fmt.Sprintf("%s is required when mode is %q.", thresholdPath, performanceModeRelative),
+			)
+		}
+	case performanceModeAbsolute:
+		if !threshold.IsNull() {
+			diags.AddAttributeWarning(
+				thresholdPath,
+				"Ignored Relative Threshold",
+				fmt.Sprintf("%s only applies when mode is %q and has no effect in the %q mode.", thresholdPath, performanceModeRelative, performanceModeAbsolute),
+			)
+		}
+	}
+}
+
+// validateEnabledSubdivisionCountries validates the continent keys and listed countries of the enabled subdivision countries
+func validateEnabledSubdivisionCountries(subdivisionCountries *enabledSubdivisionCountriesModel, diags *diag.Diagnostics) {
+	if subdivisionCountries == nil {
+		return
+	}
+
+	subdivisionsPath := path.Root("enabled_subdivision_countries")
+	for _, continentCode := range slices.Sorted(maps.Keys(subdivisionCountries.Continents)) {
+		continentPath := subdivisionsPath.AtName("continents").AtMapKey(continentCode)
+		validateContinentCode(continentCode, continentPath, diags)
+
+		continent := subdivisionCountries.Continents[continentCode]
+		if continent == nil {
+			continue
+		}
+		for i, countryCode := range continent.Countries {
+			if countryCode.IsNull() || countryCode.IsUnknown() {
+				continue
+			}
+			validateCountryCode(continentCode, countryCode.ValueString(), continentPath.AtName("countries").AtListIndex(i), diags)
+		}
+	}
+}
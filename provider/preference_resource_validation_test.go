@@ -0,0 +1,416 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance test for plan-time validation of the geographic codes of a preference configuration
+func TestAccPreferenceResource_geoValidation(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name        string
+		blocks      testAccPreferenceValidationBlocks
+		expectError *regexp.Regexp
+	}{
+		{
+			name: "unknown_threshold_continent",
+			blocks: testAccPreferenceValidationBlocks{
+				availabilityThresholds: `{
+    world = 95
+    continents = {
+      "XX" = {
+        default = 90
+        countries = {}
+      }
+    }
+  }`,
+			},
+			expectError: regexp.MustCompile(`(?s)Unknown Continent Code.*"XX" is not a continent code`),
+		},
+		{
+			name: "threshold_country_under_wrong_continent",
+			blocks: testAccPreferenceValidationBlocks{
+				availabilityThresholds: `{
+    world = 95
+    continents = {
+      "EU" = {
+        default = 95
+        countries = {
+          "JP" = 97
+        }
+      }
+    }
+  }`,
+			},
+			expectError: regexp.MustCompile(`(?s)Invalid Country Code.*JP \(Japan\) belongs to AS, not EU`),
+		},
+		{
+			name: "unknown_performance_country",
+			blocks: testAccPreferenceValidationBlocks{
+				performanceFiltering: `{
+    world = {
+      mode = "relative"
+      relative_threshold = 0.2
+    }
+    continents = {
+      "EU" = {
+        mode = "absolute"
+        countries = {
+          "EN" = {
+            mode = "relative"
+            relative_threshold = 0.3
+          }
+        }
+      }
+    }
+  }`,
+			},
+			expectError: regexp.MustCompile(`(?s)Invalid Country Code.*"EN" is not an ISO 3166-1 alpha-2 country code`),
+		},
+		{
+			name: "non_iso_subdivision_country",
+			blocks: testAccPreferenceValidationBlocks{
+				enabledSubdivisionCountries: `{
+    continents = {
+      "EU" = {
+        countries = ["DE", "UK"]
+      }
+    }
+  }`,
+			},
+			expectError: regexp.MustCompile(`(?s)Invalid Country Code.*"UK" is not an ISO 3166-1 alpha-2 country code`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: factories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccPreferenceResourceValidationConfig(mockServer.URL, tc.blocks),
+						PlanOnly:    true,
+						ExpectError: tc.expectError,
+					},
+				},
+			})
+		})
+	}
+}
+
+// Test plan-time validation of the geographic codes of a preference configuration without Terraform
+func TestPreferenceResource_geoValidation(t *testing.T) {
+	tests := []struct {
+		name                        string
+		availabilityThresholds      string
+		performanceFiltering        string
+		enabledSubdivisionCountries string
+		expectSummary               string
+		expectDetail                string
+	}{
+		{
+			name:                        "valid",
+			availabilityThresholds:      `{"world": 95, "continents": {"AS": {"default": 95, "countries": {"JP": 97}}}}`,
+			enabledSubdivisionCountries: `{"continents": {"EU": {"countries": ["DE", "GB"]}}}`,
+		},
+		{
+			name:                   "unknown_threshold_continent",
+			availabilityThresholds: `{"world": 95, "continents": {"XX": {"default": 90, "countries": {}}}}`,
+			expectSummary:          "Unknown Continent Code",
+			expectDetail:           `"XX" is not a continent code`,
+		},
+		{
+			name:                   "threshold_country_under_wrong_continent",
+			availabilityThresholds: `{"world": 95, "continents": {"EU": {"default": 95, "countries": {"JP": 97}}}}`,
+			expectSummary:          "Invalid Country Code",
+			expectDetail:           "JP (Japan) belongs to AS, not EU",
+		},
+		{
+			name: "unknown_performance_country",
+			performanceFiltering: `{"world": {"mode": "relative", "relative_threshold": 0.2}, "continents": {
+				"EU": {"mode": "absolute", "countries": {"EN": {"mode": "relative", "relative_threshold": 0.3}}}
+			}}`,
+			expectSummary: "Invalid Country Code",
+			expectDetail:  `"EN" is not an ISO 3166-1 alpha-2 country code`,
+		},
+		{
+			name:                        "non_iso_subdivision_country",
+			enabledSubdivisionCountries: `{"continents": {"EU": {"countries": ["DE", "UK"]}}}`,
+			expectSummary:               "Invalid Country Code",
+			expectDetail:                `"UK" is not an ISO 3166-1 alpha-2 country code`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := testPreferenceValidationConfig(tc.availabilityThresholds, tc.performanceFiltering, tc.enabledSubdivisionCountries)
+			diags := validateResourceConfig(t, "multicdn_preference_config", config)
+			expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tc.expectSummary, tc.expectDetail)
+		})
+	}
+}
+
+// Acceptance test for plan-time validation of the availability threshold range
+func TestAccPreferenceResource_availabilityThresholdValidation(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name                   string
+		availabilityThresholds string
+		expectError            *regexp.Regexp
+	}{
+		{
+			name: "world_above_100",
+			availabilityThresholds: `{
+    world = 100.5
+    continents = {}
+  }`,
+			expectError: regexp.MustCompile(`(?s)Invalid Attribute Value.*availability_thresholds.world value must be between`),
+		},
+		{
+			name: "negative_country",
+			availabilityThresholds: `{
+    world = 99.5
+    continents = {
+      "EU" = {
+        default = 99.9
+        countries = {
+          "DE" = -1
+        }
+      }
+    }
+  }`,
+			expectError: regexp.MustCompile(`(?s)Invalid Attribute Value.*availability_thresholds.continents\["EU"\].countries\["DE"\]`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: factories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccPreferenceResourceValidationConfig(mockServer.URL, testAccPreferenceValidationBlocks{availabilityThresholds: tc.availabilityThresholds}),
+						PlanOnly:    true,
+						ExpectError: tc.expectError,
+					},
+				},
+			})
+		})
+	}
+}
+
+// Test plan-time validation of availability thresholds and performance filtering without Terraform
+func TestPreferenceResource_thresholdValidation(t *testing.T) {
+	tests := []struct {
+		name                   string
+		availabilityThresholds string
+		performanceFiltering   string
+		expectSummary          string
+		expectDetail           string
+	}{
+		{
+			name:                   "valid",
+			availabilityThresholds: `{"world": 99.5, "continents": {"EU": {"default": 99.9, "countries": {"DE": 100}}}}`,
+			performanceFiltering: `{"world": {"mode": "relative", "relative_threshold": 0.2}, "continents": {
+				"EU": {"mode": "absolute", "countries": {"DE": {"mode": "relative", "relative_threshold": 1}}}
+			}}`,
+		},
+		{
+			name:                   "world_above_100",
+			availabilityThresholds: `{"world": 100.5, "continents": {}}`,
+			expectSummary:          "Invalid Attribute Value",
+			expectDetail:           "availability_thresholds.world value must be between",
+		},
+		{
+			name:                   "negative_country",
+			availabilityThresholds: `{"world": 99.5, "continents": {"EU": {"default": 99.9, "countries": {"DE": -1}}}}`,
+			expectSummary:          "Invalid Attribute Value",
+			expectDetail:           `availability_thresholds.continents["EU"].countries["DE"] value must be between`,
+		},
+		{
+			name:                 "unknown_mode",
+			performanceFiltering: `{"world": {"mode": "fastest", "relative_threshold": 0.2}, "continents": {}}`,
+			expectSummary:        "Invalid Attribute Value Match",
+			expectDetail:         "performance_filtering.world.mode value must be one of",
+		},
+		{
+			name: "relative_threshold_above_1",
+			performanceFiltering: `{"world": {"mode": "relative", "relative_threshold": 0.2}, "continents": {
+				"EU": {"mode": "relative", "relative_threshold": 1.5, "countries": {}}
+			}}`,
+			expectSummary: "Invalid Attribute Value",
+			expectDetail:  `performance_filtering.continents["EU"].relative_threshold value must be between`,
+		},
+		{
+			name: "relative_mode_without_threshold",
+			performanceFiltering: `{"world": {"mode": "relative", "relative_threshold": 0.2}, "continents": {
+				"NA": {"mode": "absolute", "countries": {"US": {"mode": "relative"}}}
+			}}`,
+			expectSummary: "Missing Relative Threshold",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateResourceConfig(t, "multicdn_preference_config", testPreferenceValidationConfig(tc.availabilityThresholds, tc.performanceFiltering, ""))
+			expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tc.expectSummary, tc.expectDetail)
+		})
+	}
+}
+
+// Acceptance test for plan-time validation of performance filtering modes and relative thresholds
+func TestAccPreferenceResource_performanceFilteringValidation(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name                 string
+		performanceFiltering string
+		expectError          *regexp.Regexp
+	}{
+		{
+			name: "unknown_mode",
+			performanceFiltering: `{
+    world = {
+      mode = "fastest"
+      relative_threshold = 0.2
+    }
+    continents = {}
+  }`,
+			expectError: regexp.MustCompile(`(?s)Invalid Attribute Value Match.*performance_filtering.world.mode value must be one of`),
+		},
+		{
+			name: "relative_threshold_above_1",
+			performanceFiltering: `{
+    world = {
+      mode = "relative"
+      relative_threshold = 0.2
+    }
+    continents = {
+      "EU" = {
+        mode = "relative"
+        relative_threshold = 1.5
+        countries = {}
+      }
+    }
+  }`,
+			expectError: regexp.MustCompile(`(?s)Invalid Attribute Value.*performance_filtering.continents\["EU"\].relative_threshold`),
+		},
+		{
+			name: "relative_mode_without_threshold",
+			performanceFiltering: `{
+    world = {
+      mode = "relative"
+      relative_threshold = 0.2
+    }
+    continents = {
+      "NA" = {
+        mode = "absolute"
+        countries = {
+          "US" = {
+            mode = "relative"
+          }
+        }
+      }
+    }
+  }`,
+			expectError: regexp.MustCompile(`(?s)Missing Relative Threshold.*performance_filtering.continents\["NA"\].countries\["US"\]`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: factories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccPreferenceResourceValidationConfig(mockServer.URL, testAccPreferenceValidationBlocks{performanceFiltering: tc.performanceFiltering}),
+						PlanOnly:    true,
+						ExpectError: tc.expectError,
+					},
+				},
+			})
+		})
+	}
+}
+
+// Helper function returning a preference configuration in JSON with the given attribute values,
+// empty values fall back to a valid default
+func testPreferenceValidationConfig(availabilityThresholds, performanceFiltering, enabledSubdivisionCountries string) string {
+	if availabilityThresholds == "" {
+		availabilityThresholds = `{"world": 95, "continents": {}}`
+	}
+	if performanceFiltering == "" {
+		performanceFiltering = `{"world": {"mode": "relative", "relative_threshold": 0.1}, "continents": {}}`
+	}
+	if enabledSubdivisionCountries == "" {
+		enabledSubdivisionCountries = `{"continents": {}}`
+	}
+
+	return fmt.Sprintf(`{
+		"resource_id": 12345,
+		"content_type": "application/json",
+		"availability_thresholds": %s,
+		"performance_filtering": %s,
+		"enabled_subdivision_countries": %s
+	}`, availabilityThresholds, performanceFiltering, enabledSubdivisionCountries)
+}
+
+// Attribute values of a validation test configuration, empty values fall back to a valid default
+type testAccPreferenceValidationBlocks struct {
+	availabilityThresholds      string
+	performanceFiltering        string
+	enabledSubdivisionCountries string
+}
+
+// Helper function returning a preference configuration with the given attribute values
+func testAccPreferenceResourceValidationConfig(serverURL string, blocks testAccPreferenceValidationBlocks) string {
+	if blocks.availabilityThresholds == "" {
+		blocks.availabilityThresholds = `{
+    world = 95
+    continents = {}
+  }`
+	}
+	if blocks.performanceFiltering == "" {
+		blocks.performanceFiltering = `{
+    world = {
+      mode = "relative"
+      relative_threshold = 0.1
+    }
+    continents = {}
+  }`
+	}
+	if blocks.enabledSubdivisionCountries == "" {
+		blocks.enabledSubdivisionCountries = `{
+    continents = {}
+  }`
+	}
+
+	return fmt.Sprintf(`
+provider "multicdn" {
+  api_key = "test-key"
+  api_secret = "test-secret"
+  base_url = "%s"
+}
+
+resource "multicdn_preference_config" "test" {
+  resource_id = 12345
+  content_type = "application/json"
+
+  availability_thresholds = %s
+
+  performance_filtering = %s
+
+  enabled_subdivision_countries = %s
+}
+`, serverURL, blocks.availabilityThresholds, blocks.performanceFiltering, blocks.enabledSubdivisionCountries)
+}
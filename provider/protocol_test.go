@@ -0,0 +1,177 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/provider"
+)
+
+// configureTestProvider returns a provider server configured for the fake API at url with its test credentials.
+// extraJSON holds further provider attributes as JSON object members, e.g. `"adopt_existing": true`.
+func configureTestProvider(t *testing.T, url, extraJSON string) tfprotov6.ProviderServer {
+	t.Helper()
+
+	server, diags := configureTestProviderWithDiagnostics(t, url, extraJSON)
+	if len(diags) > 0 {
+		t.Fatalf("Failed to configure the provider: %v", diags)
+	}
+
+	return server
+}
+
+// configureTestProviderWithDiagnostics is configureTestProvider for tests expecting diagnostics from the
+// provider configuration, which are returned instead of failing the test
+func configureTestProviderWithDiagnostics(t *testing.T, url, extraJSON string) (tfprotov6.ProviderServer, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	config := fmt.Sprintf(`{"api_key": "test-key", "api_secret": "test-secret", "base_url": %q`, url)
+	if extraJSON != "" {
+		config += ", " + extraJSON
+	}
+
+	server := providerserver.NewProtocol6(provider.New())()
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		Config: &tfprotov6.DynamicValue{JSON: []byte(config + "}")},
+	})
+	if err != nil {
+		t.Fatalf("ConfigureProvider returned an error: %v", err)
+	}
+
+	return server, resp.Diagnostics
+}
+
+// jsonValue returns a protocol value given as JSON, attributes left out of objects are null
+func jsonValue(value string) *tfprotov6.DynamicValue {
+	return &tfprotov6.DynamicValue{JSON: []byte(value)}
+}
+
+// testPreferenceValue returns a minimal preference with resource_id 12345 as a protocol value.
+// extraJSON holds further attributes as JSON object members, e.g. `"description": "Test"`.
+func testPreferenceValue(extraJSON string) *tfprotov6.DynamicValue {
+	value := `{
+		"resource_id": 12345,
+		"availability_thresholds": {"world": 95},
+		"performance_filtering": {"world": {"mode": "absolute"}},
+		"enabled_subdivision_countries": {}`
+	if extraJSON != "" {
+		value += ", " + extraJSON
+	}
+
+	return jsonValue(value + "}")
+}
+
+// decodeResourceValue decodes a protocol value of a resource, such as a planned or new state,
+// which the provider returns in MessagePack
+func decodeResourceValue(t *testing.T, server tfprotov6.ProviderServer, typeName string, value *tfprotov6.DynamicValue) tftypes.Value {
+	t.Helper()
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema returned an error: %v", err)
+	}
+
+	decoded, err := value.Unmarshal(schemaResp.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatalf("Failed to decode the %s value: %v", typeName, err)
+	}
+
+	return decoded
+}
+
+// diffValues returns the differences between two decoded values for failure messages
+func diffValues(expected, actual tftypes.Value) []tftypes.ValueDiff {
+	diffs, err := expected.Diff(actual)
+	if err != nil {
+		return []tftypes.ValueDiff{{Path: tftypes.NewAttributePath(), Value1: &expected, Value2: &actual}}
+	}
+	return diffs
+}
+
+// validateResourceConfig validates a resource configuration given as JSON and returns the diagnostics.
+// Validation runs without Terraform, so unlike acceptance tests it is covered by go test. Attributes left
+// out of the JSON are null.
+func validateResourceConfig(t *testing.T, typeName, config string) []*tfprotov6.Diagnostic {
+	t.Helper()
+
+	server := providerserver.NewProtocol6(provider.New())()
+	resp, err := server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   &tfprotov6.DynamicValue{JSON: []byte(config)},
+	})
+	if err != nil {
+		t.Fatalf("ValidateResourceConfig returned an error: %v", err)
+	}
+
+	return resp.Diagnostics
+}
+
+// hclResourceConfig returns the attributes of the first resource block of type typeName in an HCL test
+// configuration as JSON, so the fixtures of acceptance tests can be validated without Terraform.
+// The attributes must be literals.
+func hclResourceConfig(t *testing.T, config, typeName string) string {
+	t.Helper()
+
+	file, diags := hclsyntax.ParseConfig([]byte(config), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("Failed to parse the configuration: %s", diags)
+	}
+
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "resource" || len(block.Labels) == 0 || block.Labels[0] != typeName {
+			continue
+		}
+
+		attributes := make(map[string]json.RawMessage, len(block.Body.Attributes))
+		for name, attribute := range block.Body.Attributes {
+			value, diags := attribute.Expr.Value(nil)
+			if diags.HasErrors() {
+				t.Fatalf("Failed to evaluate %s: %s", name, diags)
+			}
+			encoded, err := ctyjson.Marshal(value, value.Type())
+			if err != nil {
+				t.Fatalf("Failed to encode %s: %v", name, err)
+			}
+			attributes[name] = encoded
+		}
+
+		encoded, err := json.Marshal(attributes)
+		if err != nil {
+			t.Fatalf("Failed to encode the %s configuration: %v", typeName, err)
+		}
+		return string(encoded)
+	}
+
+	t.Fatalf("No %s resource in the configuration", typeName)
+	return ""
+}
+
+// expectDiagnostic fails the test unless diags holds exactly one diagnostic with the given severity and summary,
+// whose detail contains the given text. An empty summary expects no diagnostics at all.
+func expectDiagnostic(t *testing.T, diags []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity, summary, detail string) {
+	t.Helper()
+
+	if summary == "" {
+		for _, d := range diags {
+			t.Errorf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+		return
+	}
+
+	if len(diags) != 1 || diags[0].Severity != severity || diags[0].Summary != summary || !strings.Contains(diags[0].Detail, detail) {
+		for _, d := range diags {
+			t.Logf("Diagnostic: %s: %s: %s", d.Severity, d.Summary, d.Detail)
+		}
+		t.Fatalf("Expected a single %s %q containing %q, got %d diagnostics", severity, summary, detail, len(diags))
+	}
+}
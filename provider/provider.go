@@ -2,13 +2,31 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+)
+
+// Environment variables used as fallbacks for unset provider attributes
+const (
+	envAPIKey    = "MULTICDN_API_KEY"
+	envAPISecret = "MULTICDN_API_SECRET"
+	envBaseURL   = "MULTICDN_BASE_URL"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -17,13 +35,39 @@ var (
 )
 
 // multiCDNProvider is the provider implementation
-type multiCDNProvider struct{}
+type multiCDNProvider struct {
+	// clientOptions are applied to the HTTP client after the options from the provider configuration
+	clientOptions []httpclient.ClientOption
+}
 
 // multiCDNProviderModel describes the provider data model
 type multiCDNProviderModel struct {
 	APIKey    types.String `tfsdk:"api_key"`
 	APISecret types.String `tfsdk:"api_secret"`
 	BaseURL   types.String `tfsdk:"base_url"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	LogAPIRequests types.Bool   `tfsdk:"log_api_requests"`
+
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	CABundle           types.String `tfsdk:"ca_bundle"`
+	ClientCertificate  types.String `tfsdk:"client_certificate"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	TLSMinVersion      types.String `tfsdk:"tls_min_version"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	RetryMaxAttempts types.Int64  `tfsdk:"retry_max_attempts"`
+	RetryBaseBackoff types.String `tfsdk:"retry_base_backoff"`
+	RetryMaxBackoff  types.String `tfsdk:"retry_max_backoff"`
+	RetryJitter      types.Bool   `tfsdk:"retry_jitter"`
+
+	MaxRequestsPerSecond  types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	AllowReservedASNs     types.Bool `tfsdk:"allow_reserved_asns"`
+	OptimisticConcurrency types.Bool `tfsdk:"optimistic_concurrency"`
+	AdoptExisting         types.Bool `tfsdk:"adopt_existing"`
+	DeletionProtection    types.Bool `tfsdk:"deletion_protection"`
 }
 
 // New creates a new instance of the provider
@@ -31,6 +75,14 @@ func New() provider.Provider {
 	return &multiCDNProvider{}
 }
 
+// NewWithClientOptions creates a new instance of the provider whose HTTP client is customized with the given
+// options, e.g. to record or replay API requests in tests
+func NewWithClientOptions(options ...httpclient.ClientOption) provider.Provider {
+	return &multiCDNProvider{
+		clientOptions: options,
+	}
+}
+
 // Metadata returns the provider metadata
 func (p *multiCDNProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "multicdn"
@@ -42,18 +94,103 @@ func (p *multiCDNProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 		Description: "Provider for managing MultiCDN API resources, including CDN and preference configurations.",
 		Attributes: map[string]schema.Attribute{
 			"api_key": schema.StringAttribute{
-				Description: "API Key for MultiCDN API authentication",
-				Required:    true,
+				Description: "API Key for MultiCDN API authentication. May also be set with the MULTICDN_API_KEY environment variable",
+				Optional:    true,
 				Sensitive:   true,
 			},
 			"api_secret": schema.StringAttribute{
-				Description: "API Secret for MultiCDN API authentication",
-				Required:    true,
+				Description: "API Secret for MultiCDN API authentication. May also be set with the MULTICDN_API_SECRET environment variable",
+				Optional:    true,
 				Sensitive:   true,
 			},
 			"base_url": schema.StringAttribute{
-				Description: "Base URL for MultiCDN API",
-				Required:    true,
+				Description: "Base URL for MultiCDN API. Required unless set with the MULTICDN_BASE_URL environment variable",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum duration of a single API request, including reading the response, as a Go duration string (e.g. \"30s\"). " +
+					"Requests timing out are retried like network errors. Set to \"0s\" to disable. Defaults to \"30s\"",
+				Optional: true,
+			},
+			"log_api_requests": schema.BoolAttribute{
+				Description: "Whether to log API requests: method, URL, status and latency at DEBUG level, plus headers and bodies at TRACE level. " +
+					"Logs are only written when enabled with TF_LOG or TF_LOG_PROVIDER, and the credentials are always redacted. Defaults to true",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy to send API requests through, e.g. \"http://proxy.example.com:3128\". " +
+					"Defaults to the proxy set in the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables",
+				Optional: true,
+			},
+			"ca_bundle": schema.StringAttribute{
+				Description: "PEM-encoded CA certificates, or the path of a file containing them, trusted in addition to the system's CAs " +
+					"to verify the API or a TLS-intercepting proxy",
+				Optional: true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "PEM-encoded client certificate, or the path of a file containing it, for mutual TLS authentication. Requires client_key",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM-encoded private key of client_certificate, or the path of a file containing it",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				Description: "Minimum TLS version accepted when connecting to the API (valid values: \"1.2\", \"1.3\"). Defaults to \"1.2\"",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("1.2", "1.3"),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Whether to skip verifying the certificate of the API. Only intended for lab environments. Defaults to false",
+				Optional:    true,
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Description: "Total number of attempts for requests failing with a retryable error, including the first one. Set to 1 to disable retries. Defaults to 4",
+				Optional:    true,
+			},
+			"retry_base_backoff": schema.StringAttribute{
+				Description: "Delay before the first retry as a Go duration string (e.g. \"500ms\"); doubled on every further retry. Defaults to \"500ms\"",
+				Optional:    true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Description: "Maximum delay between two attempts as a Go duration string, also capping Retry-After responses. Defaults to \"30s\"",
+				Optional:    true,
+			},
+			"retry_jitter": schema.BoolAttribute{
+				Description: "Whether to randomize retry delays. Defaults to true",
+				Optional:    true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Description: "Maximum average number of API requests per second, shared by all resources and data sources, " +
+					"with bursts of up to the limit rounded down. Retries count as requests. Defaults to no limit",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once, shared by all resources and data sources, " +
+					"independently of Terraform's -parallelism. Defaults to no limit",
+				Optional: true,
+			},
+			"allow_reserved_asns": schema.BoolAttribute{
+				Description: "Whether asn_overrides may use reserved, documentation and private-use ASNs. Defaults to false",
+				Optional:    true,
+			},
+			"optimistic_concurrency": schema.BoolAttribute{
+				Description: "Whether updates only apply if the configuration still has the version Terraform last read, " +
+					"failing instead of overwriting changes made outside the current run. Defaults to true",
+				Optional: true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether creating a CDN or preference configuration whose resource_id already exists adopts it instead of failing: " +
+					"the existing configuration is overwritten with the planned one and managed by Terraform from then on. " +
+					"Intended for migrating existing configurations without importing each one. Defaults to false",
+				Optional: true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Default deletion_protection of CDN and preference configurations that do not set it. Defaults to false",
+				Optional:    true,
 			},
 		},
 	}
@@ -69,41 +206,133 @@ func (p *multiCDNProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	// Unknown values cannot be resolved until apply, so there is no client to configure yet
+	for _, attribute := range []struct {
+		name   string
+		value  types.String
+		envVar string
+	}{
+		{"base_url", config.BaseURL, envBaseURL},
+		{"api_key", config.APIKey, envAPIKey},
+		{"api_secret", config.APISecret, envAPISecret},
+	} {
+		if attribute.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Unknown MultiCDN API Configuration Value",
+				fmt.Sprintf("The provider cannot create the MultiCDN API client as there is an unknown configuration value for %s. "+
+					"Either set the value statically in the configuration, or use the %s environment variable.", attribute.name, attribute.envVar),
+			)
+		}
+	}
+
+	// The other attributes have no environment variables, and unknown values would silently fall back to the defaults
+	for _, attribute := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"proxy_url", config.ProxyURL},
+		{"ca_bundle", config.CABundle},
+		{"client_certificate", config.ClientCertificate},
+		{"client_key", config.ClientKey},
+		{"tls_min_version", config.TLSMinVersion},
+		{"insecure_skip_verify", config.InsecureSkipVerify},
+		{"request_timeout", config.RequestTimeout},
+		{"log_api_requests", config.LogAPIRequests},
+		{"retry_max_attempts", config.RetryMaxAttempts},
+		{"retry_base_backoff", config.RetryBaseBackoff},
+		{"retry_max_backoff", config.RetryMaxBackoff},
+		{"retry_jitter", config.RetryJitter},
+		{"max_requests_per_second", config.MaxRequestsPerSecond},
+		{"max_concurrent_requests", config.MaxConcurrentRequests},
+		{"allow_reserved_asns", config.AllowReservedASNs},
+		{"optimistic_concurrency", config.OptimisticConcurrency},
+		{"adopt_existing", config.AdoptExisting},
+		{"deletion_protection", config.DeletionProtection},
+	} {
+		if attribute.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Unknown MultiCDN API Configuration Value",
+				fmt.Sprintf("The provider cannot create the MultiCDN API client as there is an unknown configuration value for %s. "+
+					"Set the value statically in the configuration.", attribute.name),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Configuration values take precedence over environment variables
+	baseURL := stringValueOrEnv(config.BaseURL, envBaseURL)
+	apiKey := stringValueOrEnv(config.APIKey, envAPIKey)
+	apiSecret := stringValueOrEnv(config.APISecret, envAPISecret)
+
 	// Check for required configuration
-	if config.BaseURL.IsNull() || config.BaseURL.ValueString() == "" {
+	if baseURL == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("base_url"),
 			"Missing Base URL",
-			"The provider cannot create the MultiCDN API client without the base URL",
+			"The provider cannot create the MultiCDN API client without the base URL. "+
+				"Set the base_url value in the provider configuration or use the "+envBaseURL+" environment variable.",
 		)
-		return
 	}
 
-	// Check for required configuration
-	if config.APIKey.IsNull() || config.APIKey.ValueString() == "" {
+	if apiKey == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing API Key",
-			"The provider cannot create the MultiCDN API client without an API key",
+			"The provider cannot create the MultiCDN API client without an API key. "+
+				"Set the api_key value in the provider configuration or use the "+envAPIKey+" environment variable.",
 		)
-		return
 	}
 
-	if config.APISecret.IsNull() || config.APISecret.ValueString() == "" {
+	if apiSecret == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_secret"),
 			"Missing API Secret",
-			"The provider cannot create the MultiCDN API client without an API secret",
+			"The provider cannot create the MultiCDN API client without an API secret. "+
+				"Set the api_secret value in the provider configuration or use the "+envAPISecret+" environment variable.",
 		)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create the MultiCDN client
-	client := NewAPIClient(
-		config.BaseURL.ValueString(),
-		config.APIKey.ValueString(),
-		config.APISecret.ValueString(),
-	)
+	retryPolicy := retryPolicyFromConfig(&config, &resp.Diagnostics)
+
+	requestTimeout := httpclient.DefaultRequestTimeout
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout = parseDurationAttribute(path.Root("request_timeout"), config.RequestTimeout, &resp.Diagnostics)
+	}
+
+	transportOptions := transportOptionsFromConfig(&config, &resp.Diagnostics)
+	rateLimitOptions := rateLimitOptionsFromConfig(&config, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options := []httpclient.ClientOption{
+		httpclient.WithRetryPolicy(retryPolicy),
+		httpclient.WithRequestTimeout(requestTimeout),
+		httpclient.WithRequestLogging(config.LogAPIRequests.IsNull() || config.LogAPIRequests.ValueBool()),
+		httpclient.WithRedactedValues(redactedValuesFromConfig(&config, apiKey, apiSecret)...),
+	}
+	options = append(options, transportOptions...)
+	options = append(options, rateLimitOptions...)
+	options = append(options, p.clientOptions...)
+
+	// Create the MultiCDN client. Its HTTP client is shared by all resources and data sources,
+	// so the rate and concurrency limits apply to the provider as a whole.
+	client := NewAPIClient(baseURL, apiKey, apiSecret, options...)
+	client.allowReservedASNs = config.AllowReservedASNs.ValueBool()
+	client.adoptExisting = config.AdoptExisting.ValueBool()
+	client.deletionProtection = config.DeletionProtection.ValueBool()
+	if !config.OptimisticConcurrency.IsNull() {
+		client.optimisticConcurrency = config.OptimisticConcurrency.ValueBool()
+	}
 
 	// Store the client in provider data for use in resources and data sources
 	resp.ResourceData = client
@@ -120,5 +349,124 @@ func (p *multiCDNProvider) Resources(_ context.Context) []func() resource.Resour
 
 // DataSources defines the data sources implemented in the provider
 func (p *multiCDNProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewCdnDataSource,
+		NewCdnConfigsDataSource,
+		NewPreferenceDataSource,
+		NewPreferenceConfigsDataSource,
+	}
+}
+
+// stringValueOrEnv returns the configured value, or the value of the environment variable if unset
+func stringValueOrEnv(value types.String, envVar string) string {
+	if !value.IsNull() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	return os.Getenv(envVar)
+}
+
+// redactedValuesFromConfig returns the configured secrets to mask in the request logs:
+// the API credentials, the password of the proxy URL and the client key
+func redactedValuesFromConfig(config *multiCDNProviderModel, apiKey, apiSecret string) []string {
+	values := []string{apiKey, apiSecret}
+
+	if proxyURL, err := url.Parse(config.ProxyURL.ValueString()); err == nil && proxyURL.User != nil {
+		if password, ok := proxyURL.User.Password(); ok {
+			// Also mask the password as escaped in the proxy URL
+			_, escaped, _ := strings.Cut(proxyURL.User.String(), ":")
+			values = append(values, password, escaped)
+		}
+	}
+
+	if !config.ClientKey.IsNull() {
+		values = append(values, config.ClientKey.ValueString())
+	}
+
+	return values
+}
+
+// retryPolicyFromConfig builds the HTTP retry policy from the provider configuration,
+// falling back to the client defaults for unset attributes
+func retryPolicyFromConfig(config *multiCDNProviderModel, diags *diag.Diagnostics) httpclient.RetryPolicy {
+	policy := httpclient.DefaultRetryPolicy()
+
+	if !config.RetryMaxAttempts.IsNull() {
+		if config.RetryMaxAttempts.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				path.Root("retry_max_attempts"),
+				"Invalid Retry Max Attempts",
+				fmt.Sprintf("retry_max_attempts must be at least 1, got: %d", config.RetryMaxAttempts.ValueInt64()),
+			)
+		}
+		policy.MaxAttempts = int(config.RetryMaxAttempts.ValueInt64())
+	}
+
+	if !config.RetryBaseBackoff.IsNull() {
+		policy.BaseBackoff = parseDurationAttribute(path.Root("retry_base_backoff"), config.RetryBaseBackoff, diags)
+	}
+
+	if !config.RetryMaxBackoff.IsNull() {
+		policy.MaxBackoff = parseDurationAttribute(path.Root("retry_max_backoff"), config.RetryMaxBackoff, diags)
+	}
+
+	if !config.RetryJitter.IsNull() {
+		policy.Jitter = config.RetryJitter.ValueBool()
+	}
+
+	if !diags.HasError() && policy.MaxBackoff < policy.BaseBackoff {
+		diags.AddAttributeError(
+			path.Root("retry_max_backoff"),
+			"Invalid Retry Max Backoff",
+			fmt.Sprintf("retry_max_backoff (%s) must not be lower than retry_base_backoff (%s)", policy.MaxBackoff, policy.BaseBackoff),
+		)
+	}
+
+	return policy
+}
+
+// rateLimitOptionsFromConfig builds the HTTP client rate and concurrency limits from the provider configuration.
+// Unset attributes leave the client unlimited.
+func rateLimitOptionsFromConfig(config *multiCDNProviderModel, diags *diag.Diagnostics) []httpclient.ClientOption {
+	var options []httpclient.ClientOption
+
+	if !config.MaxRequestsPerSecond.IsNull() {
+		if rate := config.MaxRequestsPerSecond.ValueFloat64(); rate <= 0 {
+			diags.AddAttributeError(
+				path.Root("max_requests_per_second"),
+				"Invalid Max Requests Per Second",
+				fmt.Sprintf("max_requests_per_second must be greater than 0, got: %g", rate),
+			)
+		} else {
+			options = append(options, httpclient.WithRateLimit(rate))
+		}
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		if limit := config.MaxConcurrentRequests.ValueInt64(); limit < 1 {
+			diags.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Max Concurrent Requests",
+				fmt.Sprintf("max_concurrent_requests must be at least 1, got: %d", limit),
+			)
+		} else {
+			options = append(options, httpclient.WithMaxConcurrentRequests(int(limit)))
+		}
+	}
+
+	return options
+}
+
+// parseDurationAttribute parses a non-negative Go duration string from a provider attribute
+func parseDurationAttribute(attrPath path.Path, value types.String, diags *diag.Diagnostics) time.Duration {
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a non-negative duration such as \"500ms\" or \"30s\", got: %q", value.ValueString()),
+		)
+		return 0
+	}
+
+	return duration
 }
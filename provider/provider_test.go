@@ -0,0 +1,397 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/multicdntest"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/preferenceclient"
+	"github.com/constellix/terraform-provider-constellix-multicdn/provider"
+)
+
+// Test that credentials and base URL can be supplied through environment variables
+func TestAccProvider_environmentConfiguration(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	t.Setenv("MULTICDN_API_KEY", "test-key")
+	t.Setenv("MULTICDN_API_SECRET", "test-secret")
+	t.Setenv("MULTICDN_BASE_URL", mockServer.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPreferenceResourceConfigWithoutProvider(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "resource_id", "12345"),
+					testAccCheckPreferenceExists(12345, mockServer),
+				),
+			},
+		},
+	})
+}
+
+// Test that missing credentials produce a clear diagnostic
+func TestAccProvider_missingCredentials(t *testing.T) {
+	mockServer, factories := setupAccProtoV6ProviderFactories()
+	defer mockServer.Close()
+
+	t.Setenv("MULTICDN_API_KEY", "")
+	t.Setenv("MULTICDN_API_SECRET", "")
+	t.Setenv("MULTICDN_BASE_URL", mockServer.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPreferenceResourceConfigWithoutProvider(),
+				ExpectError: regexp.MustCompile(`Missing API Key`),
+			},
+		},
+	})
+}
+
+// Test that the provider has no default base URL to send credentials to
+func TestProvider_missingBaseURL(t *testing.T) {
+	t.Setenv("MULTICDN_BASE_URL", "")
+
+	server := providerserver.NewProtocol6(provider.New())()
+	configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		Config: &tfprotov6.DynamicValue{JSON: []byte(`{"api_key": "test-key", "api_secret": "test-secret"}`)},
+	})
+	if err != nil {
+		t.Fatalf("ConfigureProvider returned an error: %v", err)
+	}
+
+	if len(configureResp.Diagnostics) != 1 || configureResp.Diagnostics[0].Summary != "Missing Base URL" {
+		t.Fatalf("Expected a missing base URL error, got %v", configureResp.Diagnostics)
+	}
+}
+
+// Test that attributes unknown until apply, e.g. set from another resource, are reported instead of
+// silently falling back to the defaults
+func TestProvider_unknownConfigurationValues(t *testing.T) {
+	server := providerserver.NewProtocol6(provider.New())()
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema returned an error: %v", err)
+	}
+	configType := schemaResp.Provider.ValueType().(tftypes.Object)
+
+	// Every attribute is covered, including ones added later
+	attributes := slices.Sorted(maps.Keys(configType.AttributeTypes))
+
+	for _, name := range attributes {
+		t.Run(name, func(t *testing.T) {
+			values := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+			for attribute, attributeType := range configType.AttributeTypes {
+				values[attribute] = tftypes.NewValue(attributeType, nil)
+			}
+			values["base_url"] = tftypes.NewValue(tftypes.String, "http://localhost")
+			values["api_key"] = tftypes.NewValue(tftypes.String, "test-key")
+			values["api_secret"] = tftypes.NewValue(tftypes.String, "test-secret")
+			values[name] = tftypes.NewValue(configType.AttributeTypes[name], tftypes.UnknownValue)
+
+			config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+			if err != nil {
+				t.Fatalf("Failed to encode the provider configuration: %v", err)
+			}
+
+			configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{Config: &config})
+			if err != nil {
+				t.Fatalf("ConfigureProvider returned an error: %v", err)
+			}
+			expectDiagnostic(t, configureResp.Diagnostics, tfprotov6.DiagnosticSeverityError,
+				"Unknown MultiCDN API Configuration Value", "unknown configuration value for "+name)
+		})
+	}
+}
+
+// Test that the transport attributes are validated and used to connect to the API
+func TestProvider_transportConfiguration(t *testing.T) {
+	// Every configuration is reported as deleted, so a successful read has no diagnostics
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer tlsServer.Close()
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw}))
+	caBundleFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caBundleFile, []byte(caBundle), 0o600); err != nil {
+		t.Fatalf("Failed to write the CA bundle: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		attributes      map[string]any
+		expectConfigure string
+		expectWarning   string
+		expectReadError string
+	}{
+		{
+			name:            "untrusted_server",
+			expectReadError: "certificate",
+		},
+		{
+			name:       "ca_bundle_pem",
+			attributes: map[string]any{"ca_bundle": caBundle, "tls_min_version": "1.2"},
+		},
+		{
+			name:       "ca_bundle_file",
+			attributes: map[string]any{"ca_bundle": caBundleFile},
+		},
+		{
+			name:          "insecure_skip_verify",
+			attributes:    map[string]any{"insecure_skip_verify": true},
+			expectWarning: "TLS Certificate Verification Disabled",
+		},
+		{
+			name:            "missing_ca_bundle_file",
+			attributes:      map[string]any{"ca_bundle": filepath.Join(t.TempDir(), "missing.pem")},
+			expectConfigure: "Invalid CA Bundle",
+		},
+		{
+			name:            "invalid_proxy_url",
+			attributes:      map[string]any{"proxy_url": "proxy.example.com:3128"},
+			expectConfigure: "Invalid Proxy URL",
+		},
+		{
+			name:            "client_certificate_without_key",
+			attributes:      map[string]any{"client_certificate": caBundle},
+			expectConfigure: "Incomplete Client Certificate",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes := map[string]any{"retry_max_attempts": 1}
+			maps.Copy(attributes, tc.attributes)
+			encoded, err := json.Marshal(attributes)
+			if err != nil {
+				t.Fatalf("Failed to encode the provider configuration: %v", err)
+			}
+
+			// The attributes are passed as the members of the encoded object
+			server, diags := configureTestProviderWithDiagnostics(t, tlsServer.URL, string(encoded[1:len(encoded)-1]))
+			if tc.expectConfigure != "" {
+				expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tc.expectConfigure, "")
+				return
+			}
+			expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityWarning, tc.expectWarning, "")
+
+			readResp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+				TypeName:     "multicdn_preference_config",
+				CurrentState: testPreferenceValue(""),
+			})
+			if err != nil {
+				t.Fatalf("ReadResource returned an error: %v", err)
+			}
+
+			if tc.expectReadError != "" {
+				if len(readResp.Diagnostics) != 1 || !strings.Contains(readResp.Diagnostics[0].Detail, tc.expectReadError) {
+					t.Fatalf("Expected a read error containing %q, got %v", tc.expectReadError, readResp.Diagnostics)
+				}
+				return
+			}
+			if len(readResp.Diagnostics) > 0 {
+				t.Fatalf("Unexpected diagnostics: %v", readResp.Diagnostics)
+			}
+		})
+	}
+}
+
+// Test that the rate and concurrency limits are validated and requests still succeed within them
+func TestProvider_rateLimitConfiguration(t *testing.T) {
+	mockServer := multicdntest.NewServer()
+	defer mockServer.Close()
+
+	mockServer.SetPreference(preferenceclient.Preference{ResourceID: 12345})
+
+	tests := []struct {
+		name            string
+		limits          string
+		expectConfigure string
+	}{
+		{
+			name:   "limited",
+			limits: `"max_requests_per_second": 0.5, "max_concurrent_requests": 1`,
+		},
+		{
+			name:            "zero_requests_per_second",
+			limits:          `"max_requests_per_second": 0`,
+			expectConfigure: "Invalid Max Requests Per Second",
+		},
+		{
+			name:            "zero_concurrent_requests",
+			limits:          `"max_concurrent_requests": 0`,
+			expectConfigure: "Invalid Max Concurrent Requests",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, diags := configureTestProviderWithDiagnostics(t, mockServer.URL, tc.limits)
+			expectDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tc.expectConfigure, "")
+			if tc.expectConfigure != "" {
+				return
+			}
+
+			// The first request is within the burst, so it is not delayed
+			readResp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+				TypeName:     "multicdn_preference_config",
+				CurrentState: testPreferenceValue(""),
+			})
+			if err != nil || len(readResp.Diagnostics) > 0 {
+				t.Fatalf("Failed to read the preference: %v %v", err, readResp.Diagnostics)
+			}
+		})
+	}
+}
+
+// Test that API requests are logged without the credentials, unless logging is disabled
+func TestProvider_apiRequestLogging(t *testing.T) {
+	const proxyPassword = "proxy-pass-3f9c1a"
+
+	t.Setenv("TF_ACC_LOG_PATH", "")
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", "TRACE")
+
+	mockServer := multicdntest.NewServer()
+	defer mockServer.Close()
+
+	// The description echoes the secrets, so they appear in the logged response body unless masked
+	description := "Logged description with " + multicdntest.DefaultAPISecret + " and " + proxyPassword
+	mockServer.SetPreference(preferenceclient.Preference{ResourceID: 12345, Description: description})
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(&httputil.ReverseProxy{
+		Director: func(*http.Request) { proxied.Add(1) },
+	})
+	defer proxy.Close()
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://proxy-user:"+proxyPassword+"@", 1)
+
+	tests := []struct {
+		name          string
+		logging       string
+		expectLogging bool
+	}{
+		{
+			name:          "default",
+			expectLogging: true,
+		},
+		{
+			name:    "disabled",
+			logging: `"log_api_requests": false`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			extraJSON := fmt.Sprintf(`"proxy_url": %q`, proxyURL)
+			if tc.logging != "" {
+				extraJSON += ", " + tc.logging
+			}
+			server := configureTestProvider(t, mockServer.URL, extraJSON)
+
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+			proxied.Store(0)
+
+			readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     "multicdn_preference_config",
+				CurrentState: testPreferenceValue(""),
+			})
+			if err != nil || len(readResp.Diagnostics) > 0 {
+				t.Fatalf("Failed to read the preference: %v %v", err, readResp.Diagnostics)
+			}
+			if proxied.Load() == 0 {
+				t.Fatalf("Expected the request to go through the proxy")
+			}
+
+			logged := output.String()
+			if strings.Contains(logged, "API request completed") != tc.expectLogging {
+				t.Errorf("Expected API request logging to be %t, got:\n%s", tc.expectLogging, logged)
+			}
+			if tc.expectLogging && !strings.Contains(logged, "Logged description") {
+				t.Errorf("Expected the response body to be logged at TRACE, got:\n%s", logged)
+			}
+			for name, secret := range map[string]string{"API secret": multicdntest.DefaultAPISecret, "proxy password": proxyPassword} {
+				if strings.Contains(logged, secret) {
+					t.Errorf("Expected the %s to be redacted, got:\n%s", name, logged)
+				}
+			}
+		})
+	}
+}
+
+// Preference configuration relying on the provider's environment variable fallbacks
+func testAccPreferenceResourceConfigWithoutProvider() string {
+	return `
+provider "multicdn" {}
+
+resource "multicdn_preference_config" "test" {
+  resource_id = 12345
+  content_type = "application/json"
+  description = "Configured from environment"
+
+  availability_thresholds = {
+    world = 95
+    continents = {
+      "NA" = {
+        default = 98
+        countries = {
+          "US" = 99
+        }
+      }
+    }
+  }
+
+  performance_filtering = {
+    world = {
+      mode = "relative"
+      relative_threshold = 0.8
+    }
+    continents = {
+      "NA" = {
+        mode = "relative"
+        relative_threshold = 0.9
+        countries = {
+          "US" = {
+            mode = "absolute"
+          }
+        }
+      }
+    }
+  }
+
+  enabled_subdivision_countries = {
+    continents = {
+      "NA" = {
+        countries = ["US"]
+      }
+    }
+  }
+}
+`
+}
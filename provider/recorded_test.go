@@ -0,0 +1,187 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient/cassette"
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/multicdntest"
+	"github.com/constellix/terraform-provider-constellix-multicdn/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccProviderBlock matches the provider block of a test configuration
+var testAccProviderBlock = regexp.MustCompile(`(?s)provider "multicdn" \{.*?\n\}`)
+
+// setupRecordedProviderFactories creates provider factories whose API requests are recorded to or replayed from
+// testdata/cassettes/<test name>.json, according to MULTICDN_CASSETTE_MODE. Cassettes are replayed by default,
+// and the test fails if it has none. Recording and passthrough use the live API configured through the
+// MULTICDN_BASE_URL, MULTICDN_API_KEY and MULTICDN_API_SECRET environment variables, or the fake API of
+// multicdntest if MULTICDN_BASE_URL is not set.
+func setupRecordedProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+
+	// Skip before the recorder is set up, so record mode does not save empty cassettes
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	mode, err := cassette.ModeFromEnv(cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+
+	switch {
+	case mode == cassette.ModeReplay:
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("No cassette at %s, record one with %s=%s", path, cassette.ModeEnvVar, cassette.ModeRecord)
+		}
+
+		// Requests never leave the process, so any credentials will do
+		t.Setenv("MULTICDN_BASE_URL", "https://api.multicdn.invalid")
+		t.Setenv("MULTICDN_API_KEY", "replay-key")
+		t.Setenv("MULTICDN_API_SECRET", "replay-secret")
+	case os.Getenv("MULTICDN_BASE_URL") == "":
+		mockServer := multicdntest.NewServer()
+		t.Cleanup(mockServer.Close)
+
+		t.Setenv("MULTICDN_BASE_URL", mockServer.URL)
+		t.Setenv("MULTICDN_API_KEY", multicdntest.DefaultAPIKey)
+		t.Setenv("MULTICDN_API_SECRET", multicdntest.DefaultAPISecret)
+	default:
+		for _, envVar := range []string{"MULTICDN_API_KEY", "MULTICDN_API_SECRET"} {
+			if os.Getenv(envVar) == "" {
+				t.Fatalf("%s must be set to run against the live API in %s mode", envVar, mode)
+			}
+		}
+	}
+
+	recorder, err := cassette.New(path, mode, cassette.WithRedactedValues(os.Getenv("MULTICDN_API_KEY"), os.Getenv("MULTICDN_API_SECRET")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			t.Errorf("Error saving cassette: %v", err)
+		}
+	})
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"multicdn": func() (tfprotov6.ProviderServer, error) {
+			testProvider := provider.NewWithClientOptions(recorder.ClientOption())
+			return providerserver.NewProtocol6(testProvider)(), nil
+		},
+	}
+}
+
+// testAccRecordedConfig replaces the provider block of a test configuration with one taking
+// the base URL and credentials from the environment
+func testAccRecordedConfig(config string) string {
+	return testAccProviderBlock.ReplaceAllString(config, `provider "multicdn" {}`)
+}
+
+// Test that the committed cassettes can be replayed and hold no credentials. Unlike the recorded tests
+// themselves, this does not need Terraform.
+func TestRecordedCassettes(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "cassettes", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"TestAccPreferenceResource_recorded", "TestAccCdnConfigResource_recorded"} {
+		if !slices.Contains(paths, filepath.Join("testdata", "cassettes", name+".json")) {
+			t.Errorf("Expected a cassette for %s, got %v", name, paths)
+		}
+	}
+
+	for _, path := range paths {
+		if _, err := cassette.New(path, cassette.ModeReplay); err != nil {
+			t.Errorf("Error loading cassette: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var recorded cassette.Cassette
+		if err := json.Unmarshal(data, &recorded); err != nil {
+			t.Fatalf("Error parsing cassette %s: %v", path, err)
+		}
+		for i, interaction := range recorded.Interactions {
+			if token := interaction.Request.Header.Get("x-cns-security-token"); token != cassette.Redacted {
+				t.Errorf("Expected the auth token of interaction %d in %s to be redacted, got %q", i, path, token)
+			}
+		}
+		for _, secret := range []string{multicdntest.DefaultAPIKey, multicdntest.DefaultAPISecret} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("Expected %q to be scrubbed from %s", secret, path)
+			}
+		}
+	}
+}
+
+// Lifecycle acceptance test for the preference resource against the live API or a recorded cassette
+func TestAccPreferenceResource_recorded(t *testing.T) {
+	factories := setupRecordedProviderFactories(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordedConfig(testAccPreferenceResourceConfig("", "Recorded Description")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "description", "Recorded Description"),
+					resource.TestCheckResourceAttrSet("multicdn_preference_config.test", "version"),
+					resource.TestCheckResourceAttrSet("multicdn_preference_config.test", "last_updated"),
+				),
+			},
+			{
+				Config: testAccRecordedConfig(testAccPreferenceResourceConfig("", "Updated Recorded Description")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_preference_config.test", "description", "Updated Recorded Description"),
+				),
+			},
+			{
+				ResourceName:                         "multicdn_preference_config.test",
+				ImportStateVerifyIdentifierAttribute: "resource_id",
+				ImportStateId:                        "12345",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+			},
+		},
+	})
+}
+
+// Lifecycle acceptance test for the CDN configuration resource against the live API or a recorded cassette
+func TestAccCdnConfigResource_recorded(t *testing.T) {
+	factories := setupRecordedProviderFactories(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordedConfig(testAccCdnResourceConfig("", "Recorded Description")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "description", "Recorded Description"),
+					resource.TestCheckResourceAttrSet("multicdn_cdn_config.test", "version"),
+				),
+			},
+			{
+				Config: testAccRecordedConfig(testAccCdnResourceConfig("", "Updated Recorded Description")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("multicdn_cdn_config.test", "description", "Updated Recorded Description"),
+				),
+			},
+		},
+	})
+}
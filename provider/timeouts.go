@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultOperationTimeout bounds a create, read, update or delete unless the timeouts attribute overrides it.
+// Every single request is further bounded by the provider request_timeout.
+const defaultOperationTimeout = 10 * time.Minute
+
+// timeoutsAttribute returns the standard timeouts attribute for the create, read, update and delete operations
+func timeoutsAttribute(ctx context.Context) schema.Attribute {
+	return timeouts.Attributes(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: timeoutDescription("creating"),
+		ReadDescription:   timeoutDescription("reading"),
+		UpdateDescription: timeoutDescription("updating"),
+		DeleteDescription: timeoutDescription("deleting"),
+	})
+}
+
+// nullTimeouts returns an unset timeouts attribute value
+func nullTimeouts(ctx context.Context) timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(timeoutsAttribute(ctx).GetType().(timeouts.Type).AttrTypes),
+	}
+}
+
+// timeoutDescription describes the timeout of a single operation
+func timeoutDescription(operation string) string {
+	return fmt.Sprintf("Maximum duration of %s the configuration, including retries, as a duration string such as \"30s\" or \"5m\". Defaults to \"10m\"", operation)
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/constellix/terraform-provider-constellix-multicdn/clients/httpclient"
+)
+
+// tlsVersions maps the accepted tls_min_version values to their TLS protocol versions
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// transportOptionsFromConfig builds the HTTP transport options from the provider configuration,
+// leaving the client defaults in place for unset attributes. Unknown values are rejected by Configure.
+func transportOptionsFromConfig(config *multiCDNProviderModel, diags *diag.Diagnostics) []httpclient.ClientOption {
+	var options []httpclient.ClientOption
+
+	if !config.ProxyURL.IsNull() {
+		proxyURL, err := url.Parse(config.ProxyURL.ValueString())
+		if err != nil || proxyURL.Host == "" || !isSupportedProxyScheme(proxyURL.Scheme) {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("Expected an absolute http, https or socks5 URL such as \"http://proxy.example.com:3128\", got: %q", config.ProxyURL.ValueString()),
+			)
+		} else {
+			options = append(options, httpclient.WithProxyURL(proxyURL))
+		}
+	}
+
+	if !config.CABundle.IsNull() {
+		pool, err := loadCABundle(config.CABundle)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_bundle"),
+				"Invalid CA Bundle",
+				fmt.Sprintf("Unable to load the CA bundle, expected PEM-encoded certificates or the path of a file containing them: %s", err),
+			)
+		} else {
+			options = append(options, httpclient.WithRootCAs(pool))
+		}
+	}
+
+	switch {
+	case config.ClientCertificate.IsNull() && config.ClientKey.IsNull():
+	case config.ClientCertificate.IsNull() || config.ClientKey.IsNull():
+		diags.AddAttributeError(
+			path.Root("client_certificate"),
+			"Incomplete Client Certificate",
+			"Mutual TLS authentication requires both client_certificate and client_key to be set.",
+		)
+	default:
+		certificate, err := loadClientCertificate(config.ClientCertificate, config.ClientKey)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_certificate"),
+				"Invalid Client Certificate",
+				fmt.Sprintf("Unable to load the client certificate and key, expected PEM-encoded values or the paths of files containing them: %s", err),
+			)
+		} else {
+			options = append(options, httpclient.WithClientCertificate(certificate))
+		}
+	}
+
+	if !config.TLSMinVersion.IsNull() {
+		// The value is checked by the attribute validator
+		options = append(options, httpclient.WithMinTLSVersion(tlsVersions[config.TLSMinVersion.ValueString()]))
+	}
+
+	if config.InsecureSkipVerify.ValueBool() {
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The provider does not verify the certificate of the MultiCDN API, so the connection is not protected against "+
+				"interception. Only use insecure_skip_verify against lab environments; use ca_bundle to trust a private CA instead.",
+		)
+		options = append(options, httpclient.WithInsecureSkipVerify(true))
+	}
+
+	return options
+}
+
+// isSupportedProxyScheme reports whether the Go HTTP transport can use a proxy with the given URL scheme
+func isSupportedProxyScheme(scheme string) bool {
+	switch scheme {
+	case "http", "https", "socks5":
+		return true
+	default:
+		return false
+	}
+}
+
+// loadCABundle loads the system's trusted certificate authorities extended with a CA bundle given as PEM or as a file path
+func loadCABundle(bundle types.String) (*x509.CertPool, error) {
+	bundlePEM, err := pemOrFile(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpclient.CertPoolFromPEM(bundlePEM)
+}
+
+// loadClientCertificate loads a client certificate and its private key, each given as PEM or as a file path
+func loadClientCertificate(certificate, key types.String) (tls.Certificate, error) {
+	certificatePEM, err := pemOrFile(certificate)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := pemOrFile(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certificatePEM, keyPEM)
+}
+
+// pemOrFile returns a PEM-encoded value as is, or reads it from the file the value points to
+func pemOrFile(value types.String) ([]byte, error) {
+	if strings.Contains(value.ValueString(), "-----BEGIN") {
+		return []byte(value.ValueString()), nil
+	}
+
+	return os.ReadFile(value.ValueString())
+}